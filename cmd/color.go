@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/mattn/go-isatty"
+)
+
+var (
+	// ColorFlag forces colored output
+	ColorFlag bool
+	// NoColorFlag disables colored output
+	NoColorFlag bool
+)
+
+// colorEnabled reports whether the output should be colored. --no-color wins over --color, which wins over NO_COLOR
+// and the auto-detection of a terminal
+func colorEnabled() bool {
+	if NoColorFlag {
+		return false
+	}
+	if ColorFlag {
+		return true
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
+}
+
+// setupColors enables or disables the colors of go-pretty globally
+func setupColors() {
+	if colorEnabled() {
+		text.EnableColors()
+		return
+	}
+	text.DisableColors()
+}
+
+// changeColors returns the colors for a diff change: green for added, red for removed and yellow for changed
+func changeColors(change string) text.Colors {
+	if !colorEnabled() {
+		return nil
+	}
+	switch change {
+	case "added":
+		return text.Colors{text.FgGreen}
+	case "removed":
+		return text.Colors{text.FgRed}
+	case "changed":
+		return text.Colors{text.FgYellow}
+	default:
+		return nil
+	}
+}
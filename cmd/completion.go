@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	completionCmd = &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "prints the shell completion script",
+		Long: `prints the shell completion script
+
+bash:
+  source <(pulumi-helper completion bash)
+
+zsh:
+  pulumi-helper completion zsh > "${fpath[1]}/_pulumi-helper"
+
+fish:
+  pulumi-helper completion fish | source
+
+powershell:
+  pulumi-helper completion powershell | Out-String | Invoke-Expression
+`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch args[0] {
+			case "bash":
+				return rootCmd.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return rootCmd.GenZshCompletion(os.Stdout)
+			case "fish":
+				return rootCmd.GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+			return nil
+		},
+	}
+)
+
+// completeStackNames completes the stacks of the current project for the first maxArgs arguments
+func completeStackNames(maxArgs int) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		// completions do not run the PersistentPreRunE of the root command, so --base-dir has to be applied here
+		err := applyConfig(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		stacks, err := stack.List(stack.WithBaseDir(BaseDirFlag))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		names := make([]string, 0, len(stacks))
+		for _, s := range stacks {
+			names = append(names, s.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeStateNames completes the local states for the first maxArgs arguments
+func completeStateNames(maxArgs int) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		states, err := state.List()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		names := make([]string, 0, len(states))
+		for _, s := range states {
+			names = append(names, s.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// envPrefix is the prefix of environment variables overriding the config file, e.g. PULUMI_HELPER_OUTPUT_FORMAT
+const envPrefix = "PULUMI_HELPER"
+
+// configKeys maps the keys of the config file to the flags they provide defaults for
+var configKeys = map[string]string{
+	"output_format": "output-format",
+	"log_level":     "log-level",
+	"base_dir":      "base-dir",
+}
+
+func defaultConfigFile() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(homeDir, ".pulumi-helper.toml"), nil
+}
+
+// loadConfig returns a viper instance with the flags of cmd bound to the keys of the config file and the environment
+// variables. The precedence is flag, environment variable, config file. A missing default config file is not an error
+func loadConfig(cmd *cobra.Command, file string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetEnvPrefix(envPrefix)
+	v.AutomaticEnv()
+
+	for key, flag := range configKeys {
+		err := v.BindPFlag(key, cmd.Flags().Lookup(flag))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	explicit := file != ""
+	if !explicit {
+		var err error
+		file, err = defaultConfigFile()
+		if err != nil {
+			return nil, err
+		}
+	}
+	v.SetConfigFile(file)
+
+	err := v.ReadInConfig()
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return v, nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+// applyConfig applies the config file and environment variables to all settings that were not set by flags
+func applyConfig(cmd *cobra.Command) error {
+	config, err := loadConfig(cmd, ConfigFileFlag)
+	if err != nil {
+		return err
+	}
+
+	OutputFormatFlag = config.GetString("output_format")
+	LogLevelFlag = config.GetString("log_level")
+
+	BaseDirFlag = config.GetString("base_dir")
+	stack.BaseDir = BaseDirFlag
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	helmCmd = &cobra.Command{
+		Use:     "helm",
+		Aliases: []string{"h"},
+		Short:   `works with helm charts`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			helpers.PrintInfo()
+			cmd.Help()
+			return nil
+		},
+	}
+)
+
+func init() {
+	helmCmd.AddCommand(helmTemplateCmd)
+	helmCmd.AddCommand(helmDownloadCmd)
+	helmCmd.AddCommand(helmListVersionsCmd)
+	helmCmd.AddCommand(helmSearchCmd)
+}
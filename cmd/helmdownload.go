@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/mheers/pulumi-helper/helm"
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	helmDestDir     string
+	helmCache       bool
+	helmDownloadCmd = &cobra.Command{
+		Use:     "download [chart]",
+		Aliases: []string{"pull", "dl"},
+		Short:   `downloads a helm chart to a local directory`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			src := helm.HelmChartSrc{
+				HelmChartOpts: provider.HelmChartOpts{
+					Chart:   args[0],
+					Version: helmVersion,
+					HelmFetchOpts: provider.HelmFetchOpts{
+						Repo: helmRepo,
+					},
+				},
+				DestDir: helmDestDir,
+			}
+
+			if helmCache {
+				if helmVersion == "" {
+					logrus.Warn("caching a chart without --version keeps the version of the first download")
+				}
+				src.DestDir = path.Join(helmDestDir, src.CacheKey())
+			}
+
+			if !helmCache || !src.IsDownloaded() {
+				err := src.Download()
+				if err != nil {
+					return err
+				}
+			}
+
+			chartDir, err := src.ChartDir()
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(chartDir)
+			return nil
+		},
+	}
+)
+
+func init() {
+	helmDownloadCmd.Flags().StringVarP(&helmDestDir, "dest", "d", ".", "directory to download the chart to")
+	helmDownloadCmd.Flags().StringVarP(&helmRepo, "repo", "r", "", "URL of the chart repository")
+	helmDownloadCmd.Flags().StringVarP(&helmVersion, "version", "v", "", "version of the chart (defaults to the latest)")
+	helmDownloadCmd.Flags().BoolVarP(&helmCache, "cache", "c", false, "reuse a previously downloaded chart in a subdirectory of --dest keyed by repo, chart and version")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mheers/pulumi-helper/helm"
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider"
+	"github.com/spf13/cobra"
+)
+
+var (
+	helmLatestOnly      bool
+	helmVersionsJSON    bool
+	helmListVersionsCmd = &cobra.Command{
+		Use:     "list-versions [chart]",
+		Aliases: []string{"versions", "lv"},
+		Short:   `prints the available versions of a helm chart`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			src := helm.HelmChartSrc{
+				HelmChartOpts: provider.HelmChartOpts{
+					Chart: args[0],
+					HelmFetchOpts: provider.HelmFetchOpts{
+						Repo: helmRepo,
+					},
+				},
+			}
+
+			versions, err := src.ListVersions()
+			if err != nil {
+				return err
+			}
+
+			if helmLatestOnly {
+				latest, err := helm.LatestVersion(versions)
+				if err != nil {
+					return err
+				}
+				versions = []string{latest}
+			}
+
+			if helmVersionsJSON {
+				return helpers.PrintJSON(versions)
+			}
+
+			for _, version := range versions {
+				fmt.Println(version)
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	helmListVersionsCmd.Flags().StringVarP(&helmRepo, "repo", "r", "", "URL of the chart repository")
+	helmListVersionsCmd.Flags().BoolVar(&helmLatestOnly, "latest", false, "only print the newest stable version")
+	helmListVersionsCmd.Flags().BoolVar(&helmVersionsJSON, "json", false, "print the versions as json array")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"github.com/mheers/pulumi-helper/helm"
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	helmSearchCmd = &cobra.Command{
+		Use:     "search [query]",
+		Aliases: []string{"s"},
+		Short:   `searches the configured helm repositories for charts`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if len(args) > 1 {
+				return cmd.Help()
+			}
+
+			query := ""
+			if len(args) == 1 {
+				query = args[0]
+			}
+
+			charts, err := helm.RepoSearch(query, nil)
+			if err != nil {
+				return err
+			}
+
+			return renderChartResults(charts)
+		},
+	}
+)
+
+func renderChartResults(charts []helm.ChartResult) error {
+	if OutputFormatFlag == "table" {
+		err := renderChartResultsTable(charts)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(charts)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(charts)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV(charts)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(charts)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(charts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderChartResultsTable(charts []helm.ChartResult) error {
+	rows := make([][]string, 0, len(charts))
+	for _, chart := range charts {
+		rows = append(rows, []string{
+			chart.Repo + "/" + chart.Name,
+			chart.Version,
+			chart.AppVersion,
+			chart.Description,
+		})
+	}
+	return helpers.PrintTable([]string{"Name", "Chart Version", "App Version", "Description"}, rows, helpers.TableOpts{
+		MaxColumnWidth: 50,
+		Colored:        colorEnabled(),
+	})
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mheers/pulumi-helper/helm"
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+)
+
+var (
+	helmRepo        string
+	helmVersion     string
+	helmNamespace   string
+	helmValueFiles  []string
+	helmSetValues   []string
+	helmOutputFile  string
+	helmLint        bool
+	helmTemplateCmd = &cobra.Command{
+		Use:     "template [chart]",
+		Aliases: []string{"t"},
+		Short:   `renders a helm chart to stdout`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			valueOpts := &values.Options{
+				ValueFiles: helmValueFiles,
+				Values:     helmSetValues,
+			}
+			vals, err := valueOpts.MergeValues(getter.All(cli.New()))
+			if err != nil {
+				return err
+			}
+
+			destDir, err := os.MkdirTemp("", "pulumi-helper-helm")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(destDir)
+
+			src := helm.HelmChartSrc{
+				HelmChartOpts: provider.HelmChartOpts{
+					Chart:     args[0],
+					Version:   helmVersion,
+					Namespace: helmNamespace,
+					Values:    vals,
+					HelmFetchOpts: provider.HelmFetchOpts{
+						Repo: helmRepo,
+					},
+				},
+				DestDir: destDir,
+			}
+
+			err = src.Download()
+			if err != nil {
+				return err
+			}
+
+			if helmLint {
+				err = lintChart(&src)
+				if err != nil {
+					return err
+				}
+			}
+
+			manifest, err := src.Render()
+			if err != nil {
+				return err
+			}
+
+			if helmOutputFile != "" {
+				return os.WriteFile(helmOutputFile, []byte(manifest), 0644)
+			}
+
+			fmt.Println(manifest)
+			return nil
+		},
+	}
+)
+
+// lintChart logs the lint messages of the chart and fails if one of them is an error
+func lintChart(src *helm.HelmChartSrc) error {
+	messages, err := src.Lint()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		switch m.Severity {
+		case helm.LintError:
+			logrus.Errorf("%s: %s", m.Path, m.Message)
+		case helm.LintWarning:
+			logrus.Warnf("%s: %s", m.Path, m.Message)
+		default:
+			logrus.Infof("%s: %s", m.Path, m.Message)
+		}
+	}
+
+	if helm.HasLintErrors(messages) {
+		return errors.New("chart has lint errors")
+	}
+	return nil
+}
+
+func init() {
+	helmTemplateCmd.Flags().StringVarP(&helmRepo, "repo", "r", "", "URL of the chart repository")
+	helmTemplateCmd.Flags().StringVarP(&helmVersion, "version", "v", "", "version of the chart (defaults to the latest)")
+	helmTemplateCmd.Flags().StringVarP(&helmNamespace, "namespace", "n", "", "namespace to render the chart into")
+	helmTemplateCmd.Flags().StringSliceVarP(&helmValueFiles, "values", "f", []string{}, "values file (can be specified multiple times)")
+	helmTemplateCmd.Flags().StringArrayVar(&helmSetValues, "set", []string{}, "set values (key=val, can be specified multiple times)")
+	helmTemplateCmd.Flags().BoolVar(&helmLint, "lint", false, "lint the chart before rendering and fail on lint errors")
+	helmTemplateCmd.Flags().StringVarP(&helmOutputFile, "output", "o", "", "write the manifest to this file instead of stdout")
+}
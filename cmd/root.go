@@ -14,6 +14,9 @@ var (
 	// OutputFormatFlag can be json, yaml or table
 	OutputFormatFlag string
 
+	// BaseDirFlag is the directory of the Pulumi project
+	BaseDirFlag string
+
 	// // Config holds the read config
 	// Config *config.Config
 
@@ -21,6 +24,15 @@ var (
 		Use:   "pulumi-helper",
 		Short: "pulumi-helper is a command line interface to get information about pulumi stacks and workspaces.",
 		Long:  ``,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			err := applyConfig(cmd)
+			if err != nil {
+				return err
+			}
+			helpers.SetupSlogLogger(LogLevelFlag)
+			setupColors()
+			return nil
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			helpers.PrintInfo()
 			cmd.Help()
@@ -34,9 +46,17 @@ func Execute() error {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&LogLevelFlag, "log-level", "l", "info", "possible values are debug, error, fatal, panic, info, trace")
-	rootCmd.PersistentFlags().StringVarP(&OutputFormatFlag, "output-format", "O", "table", "format [json|table|yaml|csv]")
+	rootCmd.PersistentFlags().StringVar(&ConfigFileFlag, "config", "", "path to the config file (default ~/.pulumi-helper.toml)")
+	rootCmd.PersistentFlags().StringVarP(&LogLevelFlag, "log-level", "l", "info", "log level of logrus and slog; possible values are debug, error, fatal, panic, info, trace")
+	rootCmd.PersistentFlags().StringVar(&BaseDirFlag, "base-dir", ".", "directory of the Pulumi project")
+	rootCmd.PersistentFlags().StringVarP(&OutputFormatFlag, "output-format", "O", "table", "format [json|table|yaml|csv|markdown|dotenv|xml]")
+	rootCmd.PersistentFlags().BoolVar(&ColorFlag, "color", false, "force colored output (default: auto-detect terminal)")
+	rootCmd.PersistentFlags().BoolVar(&NoColorFlag, "no-color", false, "disable colored output (also respects NO_COLOR)")
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(stackCmd)
 	rootCmd.AddCommand(workspacesCmd)
+	rootCmd.AddCommand(stateCmd)
+	rootCmd.AddCommand(helmCmd)
 }
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/mheers/pulumi-helper/helpers"
 	"github.com/mheers/pulumi-helper/stack"
 	"github.com/sirupsen/logrus"
@@ -24,6 +29,11 @@ func init() {
 	stackCmd.AddCommand(stackNameCmd)
 	stackCmd.AddCommand(stackListCmd)
 	stackCmd.AddCommand(stackSetCmd)
+	stackCmd.AddCommand(stackConfigCmd)
+	stackCmd.AddCommand(stackCreateCmd)
+	stackCmd.AddCommand(stackDeleteCmd)
+	stackCmd.AddCommand(stackDiffCmd)
+	stackCmd.AddCommand(stackOutputsCmd)
 }
 
 func dieIfNotPulumiProject() {
@@ -31,3 +41,14 @@ func dieIfNotPulumiProject() {
 		logrus.Fatal("Not a Pulumi project (no Pulumi.yaml file found)")
 	}
 }
+
+// confirm asks the user the given question and returns true if it was answered with yes
+func confirm(question string) bool {
+	fmt.Printf("%s (y/N) ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
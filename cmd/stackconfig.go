@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stackConfigCmd = &cobra.Command{
+		Use:     "config",
+		Aliases: []string{"cfg", "c"},
+		Short:   `manages the config of the current stack`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			helpers.PrintInfo()
+			cmd.Help()
+			return nil
+		},
+	}
+)
+
+func init() {
+	stackConfigCmd.AddCommand(stackConfigListCmd)
+	stackConfigCmd.AddCommand(stackConfigGetCmd)
+	stackConfigCmd.AddCommand(stackConfigSetCmd)
+	stackConfigCmd.AddCommand(stackConfigDeleteCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	yesConfigDelete      bool
+	dryRunConfigDelete   bool
+	stackConfigDeleteCmd = &cobra.Command{
+		Use:     "delete [key]",
+		Aliases: []string{"rm", "remove"},
+		Short:   `removes a config key from the current stack`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+			key := args[0]
+
+			stackName, err := stack.StackName()
+			if err != nil {
+				return err
+			}
+
+			_, err = stack.GetConfig(stackName, key)
+			if errors.Is(err, stack.ErrConfigKeyNotFound) {
+				logrus.Warnf("config key %s not found in stack %s", key, stackName)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if dryRunConfigDelete {
+				fmt.Printf("would delete %s from stack %s\n", key, stackName)
+				return nil
+			}
+
+			if !yesConfigDelete && !confirm(fmt.Sprintf("Delete %s from stack %s?", key, stackName)) {
+				return nil
+			}
+
+			err = stack.DeleteConfig(stackName, key)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("deleted %s from stack %s\n", key, stackName)
+			return nil
+		},
+	}
+)
+
+func init() {
+	stackConfigDeleteCmd.Flags().BoolVarP(&yesConfigDelete, "yes", "y", false, "skip the confirmation")
+	stackConfigDeleteCmd.Flags().BoolVar(&dryRunConfigDelete, "dry-run", false, "only print what would be deleted")
+}
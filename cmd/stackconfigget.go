@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	decryptConfigValue bool
+	stackConfigGetCmd  = &cobra.Command{
+		Use:   "get [key]",
+		Short: `prints a single config value of the current stack`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+			key := args[0]
+
+			stackName, err := stack.StackName()
+			if err != nil {
+				return err
+			}
+
+			value, err := stack.GetConfig(stackName, key)
+			if err != nil {
+				return err
+			}
+
+			if decryptConfigValue && stack.IsEncrypted(value) {
+				err = stack.InitCrypterForProject(stackName)
+				if err != nil {
+					return err
+				}
+				value, err = stack.Decrypt(value)
+				if err != nil {
+					return err
+				}
+			}
+
+			fmt.Println(value)
+			return nil
+		},
+	}
+)
+
+func init() {
+	stackConfigGetCmd.Flags().BoolVarP(&decryptConfigValue, "decrypt", "d", false, "decrypt the value (requires PULUMI_CONFIG_PASSPHRASE)")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/spf13/cobra"
+)
+
+type configEntry struct {
+	Key       string
+	Value     string
+	Encrypted bool
+}
+
+var (
+	decryptConfigList  bool
+	stackConfigListCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "l"},
+		Short:   `lists all config keys of the current stack`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			stackName, err := stack.StackName()
+			if err != nil {
+				return err
+			}
+
+			configuration, err := stack.ReadStackYaml(stackName)
+			if err != nil {
+				return err
+			}
+
+			entries := configEntries(configuration)
+			if decryptConfigList {
+				entries, err = decryptConfigEntries(stackName, entries)
+				if err != nil {
+					return err
+				}
+			}
+
+			return renderConfig(entries)
+		},
+	}
+)
+
+func init() {
+	stackConfigListCmd.Flags().BoolVarP(&decryptConfigList, "decrypt", "d", false, "decrypt encrypted values (requires PULUMI_CONFIG_PASSPHRASE)")
+}
+
+func configEntries(configuration *stack.PulumiStackYaml) []configEntry {
+	keys := make([]string, 0, len(configuration.Config))
+	for key := range configuration.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]configEntry, 0, len(keys))
+	for _, key := range keys {
+		value := configuration.Config[key]
+		entries = append(entries, configEntry{
+			Key:       key,
+			Value:     value,
+			Encrypted: stack.IsEncrypted(value),
+		})
+	}
+	return entries
+}
+
+func decryptConfigEntries(stackName string, entries []configEntry) ([]configEntry, error) {
+	err := stack.InitCrypterForProject(stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range entries {
+		if !entry.Encrypted {
+			continue
+		}
+		entries[i].Value, err = stack.Decrypt(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func renderConfig(entries []configEntry) error {
+	if OutputFormatFlag == "table" {
+		err := renderConfigListTable(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "dotenv" {
+		values := map[string]string{}
+		for _, entry := range entries {
+			value := entry.Value
+			if entry.Encrypted && !decryptConfigList {
+				value = "[secret]"
+			}
+			values[entry.Key] = value
+		}
+		helpers.PrintDotenv(values)
+	}
+	return nil
+}
+
+func renderConfigListTable(entries []configEntry) error {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Key,
+			entry.Value,
+			fmt.Sprint(entry.Encrypted),
+		})
+	}
+	return helpers.PrintTable([]string{"Key", "Value", "Encrypted"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+	})
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	secretConfig      bool
+	plaintextConfig   bool
+	stackConfigSetCmd = &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: `writes a single config value to the current stack`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+			key := args[0]
+			value := args[1]
+
+			stackName, err := stack.StackName()
+			if err != nil {
+				return err
+			}
+
+			if !stack.StackExists(stackName) {
+				return fmt.Errorf("stack file for stack %s not found", stackName)
+			}
+
+			// keep previously encrypted values encrypted unless --plaintext is given
+			encrypt := secretConfig
+			if !secretConfig && !plaintextConfig {
+				previous, err := stack.GetConfig(stackName, key)
+				encrypt = err == nil && stack.IsEncrypted(previous)
+			}
+
+			if encrypt {
+				err = stack.InitCrypterForProject(stackName)
+				if err != nil {
+					return err
+				}
+				value, err = stack.Encrypt(value)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = stack.SetConfig(stackName, key, value)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("set %s in stack %s\n", key, stackName)
+			return nil
+		},
+	}
+)
+
+func init() {
+	stackConfigSetCmd.Flags().BoolVarP(&secretConfig, "secret", "s", false, "encrypt the value (requires PULUMI_CONFIG_PASSPHRASE)")
+	stackConfigSetCmd.Flags().BoolVarP(&plaintextConfig, "plaintext", "p", false, "write the value unencrypted even if it was encrypted before")
+	stackConfigSetCmd.MarkFlagsMutuallyExclusive("secret", "plaintext")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	setActiveStack bool
+	stackCreateCmd = &cobra.Command{
+		Use:     "create [name]",
+		Aliases: []string{"new", "init"},
+		Short:   `creates a new stack file`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+			name := args[0]
+
+			file, err := stack.CreateStack(name)
+			if err != nil {
+				return err
+			}
+			fmt.Println(file)
+
+			if setActiveStack {
+				return stack.SetStack(name)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	stackCreateCmd.Flags().BoolVarP(&setActiveStack, "set-active", "a", false, "select the new stack as the current stack")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forceStackDelete bool
+	removeState      bool
+	stackDeleteCmd   = &cobra.Command{
+		Use:     "delete [name]",
+		Aliases: []string{"rm", "remove"},
+		Short:   `removes a stack file`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+			name := args[0]
+
+			if !stack.StackExists(name) {
+				return fmt.Errorf("stack %s not found", name)
+			}
+
+			currentStack, err := stack.StackName()
+			if err == nil && currentStack == name && !forceStackDelete {
+				return fmt.Errorf("stack %s is the current stack; use --force to delete it anyway", name)
+			}
+
+			if !forceStackDelete && !confirm("Are you sure?") {
+				return nil
+			}
+
+			err = stack.DeleteStack(name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("deleted stack %s\n", name)
+
+			if removeState {
+				err = state.RemoveState(name)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("deleted state %s\n", name)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	stackDeleteCmd.ValidArgsFunction = completeStackNames(1)
+	stackDeleteCmd.Flags().BoolVarP(&forceStackDelete, "force", "f", false, "skip the confirmation")
+	stackDeleteCmd.Flags().BoolVar(&removeState, "remove-state", false, "also remove the local state file from ~/.pulumi/stacks")
+}
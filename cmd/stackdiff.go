@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/spf13/cobra"
+)
+
+type configDiffEntry struct {
+	Key      string
+	OldValue string
+	NewValue string
+	Change   string
+}
+
+var (
+	stackDiffCmd = &cobra.Command{
+		Use:     "diff [stack-a] [stack-b]",
+		Aliases: []string{"d"},
+		Short:   `compares the config of two stacks`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+
+			diff, err := stack.DiffConfigs(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return renderConfigDiff(diff)
+		},
+	}
+)
+
+func configDiffEntries(diff stack.ConfigDiff) []configDiffEntry {
+	keys := make([]string, 0, len(diff))
+	for key := range diff {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]configDiffEntry, 0, len(keys))
+	for _, key := range keys {
+		change := diff[key]
+		entries = append(entries, configDiffEntry{
+			Key:      key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+			Change:   change.Change,
+		})
+	}
+	return entries
+}
+
+func renderConfigDiff(diff stack.ConfigDiff) error {
+	if OutputFormatFlag == "table" {
+		err := renderConfigDiffTable(configDiffEntries(diff))
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(diff)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(diff)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV(configDiffEntries(diff))
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(configDiffEntries(diff))
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(configDiffEntries(diff))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderConfigDiffTable(entries []configDiffEntry) error {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Key,
+			entry.OldValue,
+			entry.NewValue,
+			entry.Change,
+		})
+	}
+	return helpers.PrintTable([]string{"Key", "Old Value", "New Value", "Change"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+		RowColors: func(row []string) text.Colors {
+			return changeColors(row[3])
+		},
+	})
+}
+
+func init() {
+	stackDiffCmd.ValidArgsFunction = completeStackNames(2)
+}
@@ -1,9 +1,9 @@
 package cmd
 
 import (
-	"os"
+	"strings"
+	"time"
 
-	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/mheers/pulumi-helper/helpers"
 	"github.com/mheers/pulumi-helper/stack"
 	"github.com/spf13/cobra"
@@ -20,19 +20,34 @@ var (
 
 			dieIfNotPulumiProject()
 
-			stacks, err := stack.List()
-			if err != nil {
-				return err
+			list := func() error {
+				stacks, err := stack.List()
+				if err != nil {
+					return err
+				}
+
+				return renderStacks(stacks)
 			}
 
-			return renderStacks(stacks)
+			if watchFlag {
+				return watch(intervalFlag, list)
+			}
+			return list()
 		},
 	}
 )
 
+func init() {
+	stackListCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "re-render the list every --interval")
+	stackListCmd.Flags().DurationVar(&intervalFlag, "interval", 5*time.Second, "refresh interval for --watch")
+}
+
 func renderStacks(stacks []stack.Stack) error {
 	if OutputFormatFlag == "table" {
-		renderStackListTable(stacks)
+		err := renderStackListTable(stacks)
+		if err != nil {
+			return err
+		}
 	}
 	if OutputFormatFlag == "json" {
 		err := helpers.PrintJSON(stacks)
@@ -52,21 +67,36 @@ func renderStacks(stacks []stack.Stack) error {
 			return err
 		}
 	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(stacks)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(stacks)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "dotenv" {
+		names := make([]string, 0, len(stacks))
+		for _, stack := range stacks {
+			names = append(names, stack.Name)
+		}
+		// PULUMI_STACKS is a valid shell identifier, unlike the config style "pulumi:stacks"
+		helpers.PrintDotenv(map[string]string{helpers.DotenvKey("pulumi:stacks"): strings.Join(names, " ")})
+	}
 	return nil
 }
 
-func renderStackListTable(stacks []stack.Stack) {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Name"})
+func renderStackListTable(stacks []stack.Stack) error {
+	rows := make([][]string, 0, len(stacks))
 	for _, stack := range stacks {
-		t.AppendRow(
-			table.Row{
-				stack.Name,
-			},
-		)
-
-		t.AppendSeparator()
+		rows = append(rows, []string{stack.Name})
 	}
-	t.Render()
+	return helpers.PrintTable([]string{"Name"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+	})
 }
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+)
+
+type outputEntry struct {
+	Key    string
+	Value  string
+	Secret bool
+}
+
+var (
+	decryptOutputs  bool
+	stackOutputsCmd = &cobra.Command{
+		Use:     "outputs [stack-name]",
+		Aliases: []string{"output", "o"},
+		Short:   `lists all outputs of a stack (defaults to the current stack)`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			var stackName string
+			if len(args) > 0 {
+				stackName = args[0]
+			} else {
+				dieIfNotPulumiProject()
+
+				var err error
+				stackName, err = stack.StackName()
+				if err != nil {
+					return err
+				}
+			}
+
+			s, err := state.GetState(stackName)
+			if err != nil {
+				return err
+			}
+
+			outputs, err := s.Outputs()
+			if err != nil {
+				return err
+			}
+
+			if decryptOutputs {
+				err = stack.InitCrypterForProject(stackName)
+				if err != nil {
+					return err
+				}
+			}
+
+			entries, err := outputEntries(outputs)
+			if err != nil {
+				return err
+			}
+
+			return renderOutputs(entries)
+		},
+	}
+)
+
+func init() {
+	stackOutputsCmd.ValidArgsFunction = completeStateNames(1)
+	stackOutputsCmd.Flags().BoolVarP(&decryptOutputs, "decrypt", "d", false, "decrypt secret outputs (requires PULUMI_CONFIG_PASSPHRASE)")
+}
+
+func outputEntries(outputs map[string]gjson.Result) ([]outputEntry, error) {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]outputEntry, 0, len(keys))
+	for _, key := range keys {
+		output := outputs[key]
+		entry := outputEntry{
+			Key:    key,
+			Value:  output.String(),
+			Secret: state.IsSecret(output),
+		}
+
+		if entry.Secret {
+			entry.Value = "[secret]"
+			if decryptOutputs {
+				decrypted, err := stack.Decrypt(output.Get("ciphertext").String())
+				if err != nil {
+					return nil, err
+				}
+				entry.Value = decrypted
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func renderOutputs(entries []outputEntry) error {
+	if OutputFormatFlag == "table" {
+		err := renderOutputListTable(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderOutputListTable(entries []outputEntry) error {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Key,
+			entry.Value,
+			fmt.Sprint(entry.Secret),
+		})
+	}
+	return helpers.PrintTable([]string{"Key", "Value", "Secret"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+	})
+}
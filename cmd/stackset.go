@@ -26,3 +26,7 @@ var (
 		},
 	}
 )
+
+func init() {
+	stackSetCmd.ValidArgsFunction = completeStackNames(1)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateCmd = &cobra.Command{
+		Use:     "states",
+		Aliases: []string{"state"},
+		Short:   `inspects local state files`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			helpers.PrintInfo()
+			cmd.Help()
+			return nil
+		},
+	}
+)
+
+func init() {
+	stateCmd.AddCommand(stateListCmd)
+	stateCmd.AddCommand(stateResourcesCmd)
+	stateCmd.AddCommand(stateDiffCmd)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/spf13/cobra"
+)
+
+type stateDiffEntry struct {
+	URN     string
+	Type    string
+	Change  string
+	Outputs string
+}
+
+var (
+	onlyChanged  bool
+	stateDiffCmd = &cobra.Command{
+		Use:     "diff [state-a] [state-b]",
+		Aliases: []string{"d"},
+		Short:   `compares the resources of two states`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+
+			a, err := state.GetState(args[0])
+			if err != nil {
+				return err
+			}
+			b, err := state.GetState(args[1])
+			if err != nil {
+				return err
+			}
+
+			diffs, err := state.DiffStates(a, b)
+			if err != nil {
+				return err
+			}
+
+			if onlyChanged {
+				changed := []state.ResourceDiff{}
+				for _, diff := range diffs {
+					if diff.Change != state.ResourceUnchanged {
+						changed = append(changed, diff)
+					}
+				}
+				diffs = changed
+			}
+
+			return renderStateDiff(diffs)
+		},
+	}
+)
+
+func init() {
+	stateDiffCmd.ValidArgsFunction = completeStateNames(2)
+	stateDiffCmd.Flags().BoolVarP(&onlyChanged, "only-changed", "c", false, "hide unchanged resources")
+}
+
+func stateDiffEntries(diffs []state.ResourceDiff) []stateDiffEntry {
+	entries := make([]stateDiffEntry, 0, len(diffs))
+	for _, diff := range diffs {
+		entries = append(entries, stateDiffEntry{
+			URN:     diff.URN,
+			Type:    diff.Type,
+			Change:  diff.Change,
+			Outputs: strings.Join(diff.Outputs, "\n"),
+		})
+	}
+	return entries
+}
+
+func renderStateDiff(diffs []state.ResourceDiff) error {
+	if OutputFormatFlag == "json" {
+		return helpers.PrintJSON(diffs)
+	}
+	if OutputFormatFlag == "yaml" {
+		return helpers.PrintYAML(diffs)
+	}
+	if OutputFormatFlag == "markdown" {
+		return helpers.PrintMarkdown(stateDiffEntries(diffs))
+	}
+	if OutputFormatFlag == "xml" {
+		return helpers.PrintXML(diffs)
+	}
+
+	for _, diff := range diffs {
+		colors := changeColors(diff.Change)
+		fmt.Println(colors.Sprintf("%s %s", stateDiffMarker(diff.Change), diff.URN))
+		for _, line := range diff.Outputs {
+			fmt.Println(colors.Sprintf("    %s", line))
+		}
+	}
+	return nil
+}
+
+func stateDiffMarker(change string) string {
+	switch change {
+	case state.ResourceAdded:
+		return "+"
+	case state.ResourceRemoved:
+		return "-"
+	case state.ResourceChanged:
+		return "~"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/spf13/cobra"
+)
+
+type stateEntry struct {
+	Name          string
+	Path          string
+	ModTime       time.Time
+	ResourceCount int
+}
+
+var (
+	stateListCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "l", "ps"},
+		Short:   `lists all local state files`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if watchFlag {
+				return watchStates()
+			}
+
+			states, err := state.List()
+			if err != nil {
+				return err
+			}
+
+			entries, err := stateEntries(states)
+			if err != nil {
+				return err
+			}
+
+			return renderStates(entries)
+		},
+	}
+)
+
+func init() {
+	stateListCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "re-render the list whenever a state file changes")
+	stateListCmd.Flags().DurationVar(&intervalFlag, "interval", 5*time.Second, "polling interval for --watch")
+}
+
+func watchStates() error {
+	ctx, stop := watchContext()
+	defer stop()
+
+	ch := make(chan []state.State)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- state.Watch(ctx, intervalFlag, ch)
+	}()
+
+	for {
+		select {
+		case states := <-ch:
+			entries, err := stateEntries(states)
+			if err != nil {
+				return err
+			}
+			fmt.Print(clearScreen)
+			err = renderStates(entries)
+			if err != nil {
+				return err
+			}
+		case err := <-errCh:
+			return err
+		}
+	}
+}
+
+func stateEntries(states []state.State) ([]stateEntry, error) {
+	entries := make([]stateEntry, 0, len(states))
+	for _, s := range states {
+		counts, err := s.CountByType()
+		if err != nil {
+			return nil, err
+		}
+
+		resourceCount := 0
+		for _, count := range counts {
+			resourceCount += count
+		}
+
+		entries = append(entries, stateEntry{
+			Name:          s.Name,
+			Path:          s.Path,
+			ModTime:       s.ModTime,
+			ResourceCount: resourceCount,
+		})
+	}
+	return entries, nil
+}
+
+func renderStates(entries []stateEntry) error {
+	if OutputFormatFlag == "table" {
+		err := renderStateListTable(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderStateListTable(entries []stateEntry) error {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Name,
+			entry.Path,
+			fmt.Sprint(entry.ModTime),
+			fmt.Sprint(entry.ResourceCount),
+		})
+	}
+	return helpers.PrintTable([]string{"Name", "Path", "Modified", "Resource Count"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+	})
+}
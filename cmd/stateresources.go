@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/spf13/cobra"
+)
+
+type resourceEntry struct {
+	URN  string
+	Type string
+	ID   string
+}
+
+var (
+	resourceTypeFilter string
+	resourceLimit      int
+	resourceOffset     int
+	stateResourcesCmd  = &cobra.Command{
+		Use:     "resources [state-name]",
+		Aliases: []string{"res", "r"},
+		Short:   `lists the resources of a state`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			s, err := state.GetState(args[0])
+			if err != nil {
+				return err
+			}
+
+			resources, err := s.Resources()
+			if err != nil {
+				return err
+			}
+
+			if resourceTypeFilter != "" {
+				resources = state.FilterResourcesByType(resources, resourceTypeFilter)
+			}
+
+			return renderResources(paginateResources(resourceEntries(resources)))
+		},
+	}
+)
+
+func init() {
+	stateResourcesCmd.ValidArgsFunction = completeStateNames(1)
+	stateResourcesCmd.Flags().StringVarP(&resourceTypeFilter, "type", "t", "", "only list resources of this type (supports * and ? wildcards)")
+	stateResourcesCmd.Flags().IntVar(&resourceLimit, "limit", 0, "maximum number of resources to list (0 lists all)")
+	stateResourcesCmd.Flags().IntVar(&resourceOffset, "offset", 0, "number of resources to skip")
+}
+
+func resourceEntries(resources []state.Resource) []resourceEntry {
+	entries := make([]resourceEntry, 0, len(resources))
+	for _, resource := range resources {
+		entries = append(entries, resourceEntry{
+			URN:  resource.URN,
+			Type: resource.Type,
+			ID:   resource.ID,
+		})
+	}
+	return entries
+}
+
+func paginateResources(entries []resourceEntry) []resourceEntry {
+	if resourceOffset >= len(entries) {
+		return []resourceEntry{}
+	}
+	if resourceOffset > 0 {
+		entries = entries[resourceOffset:]
+	}
+	if resourceLimit > 0 && resourceLimit < len(entries) {
+		entries = entries[:resourceLimit]
+	}
+	return entries
+}
+
+func renderResources(entries []resourceEntry) error {
+	if OutputFormatFlag == "table" {
+		err := renderResourceListTable(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(entries)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(entries)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderResourceListTable(entries []resourceEntry) error {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.URN,
+			entry.Type,
+			entry.ID,
+		})
+	}
+	return helpers.PrintTable([]string{"URN", "Type", "ID"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+	})
+}
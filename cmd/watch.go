@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+const (
+	clearScreen   = "\033[H\033[2J"
+	hideCursor    = "\033[?25l"
+	restoreCursor = "\033[?25h"
+)
+
+var (
+	watchFlag    bool
+	intervalFlag time.Duration
+)
+
+// watchContext returns a context that is cancelled on SIGINT. The returned stop function restores the terminal
+func watchContext() (context.Context, func()) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	fmt.Print(hideCursor)
+	return ctx, func() {
+		cancel()
+		fmt.Print(restoreCursor)
+	}
+}
+
+// watch re-runs render every interval until SIGINT is received
+func watch(interval time.Duration, render func() error) error {
+	ctx, stop := watchContext()
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fmt.Print(clearScreen)
+		if err := render(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -20,4 +20,5 @@ var (
 
 func init() {
 	workspacesCmd.AddCommand(workspacesListCmd)
+	workspacesCmd.AddCommand(workspacesInfoCmd)
 }
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mheers/pulumi-helper/helpers"
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/mheers/pulumi-helper/workspace"
+	"github.com/spf13/cobra"
+)
+
+type workspaceInfo struct {
+	Project string
+	Hash    string
+	Stack   string
+	Path    string
+	ModTime time.Time
+}
+
+var (
+	workspacesInfoCmd = &cobra.Command{
+		Use:     "info",
+		Short:   "prints details about the workspace of the current project",
+		Aliases: []string{"i"},
+		Long:    ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Set the log level
+			helpers.SetLogLevel(LogLevelFlag)
+
+			dieIfNotPulumiProject()
+
+			project, err := stack.ProjectName()
+			if err != nil {
+				return err
+			}
+
+			spaces, err := workspace.GetWorkspaces()
+			if err != nil {
+				return err
+			}
+
+			space, ok := spaces[project]
+			if !ok {
+				return fmt.Errorf("no workspace found for project %s", project)
+			}
+
+			return renderWorkspaceInfo(workspaceInfo{
+				Project: space.Name,
+				Hash:    space.Hash,
+				Stack:   space.Stack,
+				Path:    space.File.Path,
+				ModTime: space.File.ModTime,
+			})
+		},
+	}
+)
+
+func renderWorkspaceInfo(info workspaceInfo) error {
+	if OutputFormatFlag == "table" {
+		err := renderWorkspaceInfoTable(info)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "json" {
+		err := helpers.PrintJSON(info)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "yaml" {
+		err := helpers.PrintYAML(info)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "csv" {
+		err := helpers.PrintCSV([]workspaceInfo{info})
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown([]workspaceInfo{info})
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML([]workspaceInfo{info})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderWorkspaceInfoTable(info workspaceInfo) error {
+	rows := [][]string{
+		{"Project", info.Project},
+		{"Hash", info.Hash},
+		{"Current Stack", info.Stack},
+		{"Path", info.Path},
+		{"Modified", fmt.Sprint(info.ModTime)},
+	}
+	return helpers.PrintTable([]string{"Property", "Value"}, rows, helpers.TableOpts{
+		Colored: colorEnabled(),
+	})
+}
@@ -1,9 +1,8 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
 
-	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/mheers/pulumi-helper/helpers"
 	"github.com/mheers/pulumi-helper/workspace"
 	"github.com/spf13/cobra"
@@ -31,7 +30,10 @@ var (
 
 func renderWorkspaces(spaces []workspace.Workspace) error {
 	if OutputFormatFlag == "table" {
-		renderWorkspaceListTable(spaces)
+		err := renderWorkspaceListTable(spaces)
+		if err != nil {
+			return err
+		}
 	}
 	if OutputFormatFlag == "json" {
 		err := helpers.PrintJSON(spaces)
@@ -51,23 +53,32 @@ func renderWorkspaces(spaces []workspace.Workspace) error {
 			return err
 		}
 	}
+	if OutputFormatFlag == "markdown" {
+		err := helpers.PrintMarkdown(spaces)
+		if err != nil {
+			return err
+		}
+	}
+	if OutputFormatFlag == "xml" {
+		err := helpers.PrintXML(spaces)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func renderWorkspaceListTable(spaces []workspace.Workspace) {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Name", "Current Stack", "Modified"})
+func renderWorkspaceListTable(spaces []workspace.Workspace) error {
+	rows := make([][]string, 0, len(spaces))
 	for _, space := range spaces {
-		t.AppendRow(
-			table.Row{
-				space.Name,
-				space.Stack,
-				space.File.ModTime,
-			},
-		)
-
-		t.AppendSeparator()
+		rows = append(rows, []string{
+			space.Name,
+			space.Stack,
+			fmt.Sprint(space.File.ModTime),
+		})
 	}
-	t.Render()
+	return helpers.PrintTable([]string{"Name", "Current Stack", "Modified"}, rows, helpers.TableOpts{
+		RowSeparators: true,
+		Colored:       colorEnabled(),
+	})
 }
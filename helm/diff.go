@@ -0,0 +1,108 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// Diff renders the chart in its current version and in otherVersion and returns a unified diff of the manifests.
+// The resources are sorted by GVK, namespace and name first, so a different order does not show up in the diff
+func (c *HelmChartSrc) Diff(otherVersion string, values map[string]interface{}, namespace string) (string, error) {
+	current, err := c.renderVersion(c.Version, values, namespace)
+	if err != nil {
+		return "", err
+	}
+	other, err := c.renderVersion(otherVersion, values, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	currentName := c.Version
+	if currentName == "" {
+		currentName = "current"
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(other),
+		FromFile: currentName,
+		ToFile:   otherVersion,
+		Context:  3,
+	})
+}
+
+// renderVersion downloads the given version of the chart to a temporary directory and returns its sorted manifest
+func (c *HelmChartSrc) renderVersion(version string, values map[string]interface{}, namespace string) (string, error) {
+	destDir, err := os.MkdirTemp("", "pulumi-helper-helm")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(destDir)
+
+	src := *c
+	src.Version = version
+	src.Values = values
+	src.Namespace = namespace
+	src.DestDir = destDir
+
+	err = src.Download()
+	if err != nil {
+		return "", fmt.Errorf("could not download version %s: %w", version, err)
+	}
+
+	manifest, err := src.Render()
+	if err != nil {
+		return "", err
+	}
+
+	return sortManifest(manifest)
+}
+
+// sortManifest sorts the documents of a multi-document YAML manifest by apiVersion, kind, namespace and name
+func sortManifest(manifest string) (string, error) {
+	type document struct {
+		key     string
+		content string
+	}
+
+	var documents []document
+	for _, content := range strings.Split("\n"+manifest, "\n---") {
+		content = strings.TrimSpace(content)
+		if content == "" {
+			continue
+		}
+
+		var resource struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		err := yaml.Unmarshal([]byte(content), &resource)
+		if err != nil {
+			return "", err
+		}
+
+		documents = append(documents, document{
+			key:     strings.Join([]string{resource.APIVersion, resource.Kind, resource.Metadata.Namespace, resource.Metadata.Name}, "/"),
+			content: content,
+		})
+	}
+
+	sort.SliceStable(documents, func(i, j int) bool {
+		return documents[i].key < documents[j].key
+	})
+
+	contents := make([]string, 0, len(documents))
+	for _, d := range documents {
+		contents = append(contents, d.content)
+	}
+	return strings.Join(contents, "\n---\n") + "\n", nil
+}
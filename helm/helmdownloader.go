@@ -1,6 +1,8 @@
 package helm
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider"
+	"github.com/sirupsen/logrus"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/registry"
@@ -28,6 +31,22 @@ func (c *HelmChartSrc) Download() error {
 	return c.fetch()
 }
 
+// CacheKey returns a key that identifies the chart by its repository, name and version
+func (c *HelmChartSrc) CacheKey() string {
+	version := c.Version
+	if version == "" {
+		version = c.HelmFetchOpts.Version
+	}
+	h := sha256.Sum256([]byte(strings.Join([]string{c.Repo, c.HelmFetchOpts.Repo, c.Chart, version}, "|")))
+	return hex.EncodeToString(h[:])
+}
+
+// IsDownloaded returns true if the chart was already downloaded to DestDir
+func (c *HelmChartSrc) IsDownloaded() bool {
+	_, err := c.ChartDir()
+	return err == nil
+}
+
 func (c *HelmChartSrc) Path() string {
 	return path.Join(c.DestDir, "chart")
 }
@@ -95,7 +114,7 @@ func (c *HelmChartSrc) fetch() error {
 	if err != nil {
 		return errors.New("failed to pull chart")
 	}
-	fmt.Println(downloadInfo)
+	logrus.Info(downloadInfo)
 	return nil
 }
 
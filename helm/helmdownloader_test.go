@@ -1,10 +1,13 @@
 package helm
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider"
 	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
 )
 
 func TestDownloadNifi(t *testing.T) {
@@ -29,3 +32,149 @@ func TestDownloadZookeeper(t *testing.T) {
 	err := src.Download()
 	require.NoError(t, err)
 }
+
+func TestRenderZookeeper(t *testing.T) {
+	src := HelmChartSrc{
+		HelmChartOpts: provider.HelmChartOpts{
+			Chart:     "zookeeper",
+			Namespace: "zk",
+			HelmFetchOpts: provider.HelmFetchOpts{
+				Repo: "https://charts.bitnami.com/bitnami",
+			},
+		},
+		DestDir: t.TempDir(),
+	}
+	err := src.Download()
+	require.NoError(t, err)
+
+	manifest, err := src.Render()
+	require.NoError(t, err)
+	require.Contains(t, manifest, "kind: StatefulSet")
+	require.Contains(t, manifest, "namespace: zk")
+}
+
+func TestCacheKey(t *testing.T) {
+	a := HelmChartSrc{
+		HelmChartOpts: provider.HelmChartOpts{
+			Chart:   "zookeeper",
+			Version: "1.0.0",
+		},
+	}
+	b := HelmChartSrc{
+		HelmChartOpts: provider.HelmChartOpts{
+			Chart:   "zookeeper",
+			Version: "1.0.1",
+		},
+	}
+	require.Equal(t, a.CacheKey(), a.CacheKey())
+	require.NotEqual(t, a.CacheKey(), b.CacheKey())
+}
+
+func TestListVersionsZookeeper(t *testing.T) {
+	src := HelmChartSrc{
+		HelmChartOpts: provider.HelmChartOpts{
+			Chart: "zookeeper",
+			HelmFetchOpts: provider.HelmFetchOpts{
+				Repo: "https://charts.bitnami.com/bitnami",
+			},
+		},
+	}
+	versions, err := src.ListVersions()
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+}
+
+func TestLatestVersion(t *testing.T) {
+	latest, err := LatestVersion([]string{"2.0.0-rc.1", "1.2.3", "1.2.2"})
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", latest)
+
+	_, err = LatestVersion([]string{"2.0.0-rc.1"})
+	require.Error(t, err)
+}
+
+func TestDiffZookeeper(t *testing.T) {
+	src := HelmChartSrc{
+		HelmChartOpts: provider.HelmChartOpts{
+			Chart:   "zookeeper",
+			Version: "13.0.0",
+			HelmFetchOpts: provider.HelmFetchOpts{
+				Repo: "https://charts.bitnami.com/bitnami",
+			},
+		},
+	}
+	diff, err := src.Diff("13.1.0", nil, "zk")
+	require.NoError(t, err)
+	require.Contains(t, diff, "--- 13.0.0")
+	require.Contains(t, diff, "+++ 13.1.0")
+	require.Contains(t, diff, "helm.sh/chart: zookeeper-13.1.0")
+
+	diff, err = src.Diff("13.0.0", nil, "zk")
+	require.NoError(t, err)
+	require.Empty(t, diff)
+}
+
+func TestSortManifest(t *testing.T) {
+	sorted, err := sortManifest("---\napiVersion: v1\nkind: Service\nmetadata:\n  name: b\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n")
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: b\n", sorted)
+}
+
+func TestRepoSearch(t *testing.T) {
+	settings := cli.New()
+	settings.RepositoryConfig = path.Join(t.TempDir(), "repositories.yaml")
+	settings.RepositoryCache = t.TempDir()
+
+	_, err := RepoSearch("zookeeper", settings)
+	require.Error(t, err)
+
+	err = os.WriteFile(settings.RepositoryConfig, []byte("repositories:\n- name: demo\n  url: https://charts.example.com\n"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(settings.RepositoryCache, "demo-index.yaml"), []byte(`apiVersion: v1
+entries:
+  zookeeper:
+  - name: zookeeper
+    version: 13.0.0
+    appVersion: 3.9.2
+    description: Apache ZooKeeper
+    urls:
+    - https://charts.example.com/zookeeper-13.0.0.tgz
+  nginx:
+  - name: nginx
+    version: 16.0.0
+    description: NGINX web server
+    urls:
+    - https://charts.example.com/nginx-16.0.0.tgz
+`), 0644)
+	require.NoError(t, err)
+
+	charts, err := RepoSearch("zookeeper", settings)
+	require.NoError(t, err)
+	require.Equal(t, []ChartResult{{
+		Name:        "zookeeper",
+		Version:     "13.0.0",
+		Description: "Apache ZooKeeper",
+		AppVersion:  "3.9.2",
+		Repo:        "demo",
+	}}, charts)
+
+	charts, err = RepoSearch("", settings)
+	require.NoError(t, err)
+	require.Len(t, charts, 2)
+}
+
+func TestLint(t *testing.T) {
+	src := HelmChartSrc{
+		HelmChartOpts: provider.HelmChartOpts{
+			Path: "../helmx/testdata/demo",
+		},
+	}
+	messages, err := src.Lint()
+	require.NoError(t, err)
+	require.False(t, HasLintErrors(messages))
+	require.Contains(t, messages, LintMessage{Severity: LintInfo, Path: "Chart.yaml", Message: "icon is recommended"})
+
+	src.HelmChartOpts.Path = path.Join(t.TempDir(), "missing")
+	_, err = src.Lint()
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package helm
+
+import (
+	"errors"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+const (
+	LintInfo    = "info"
+	LintWarning = "warning"
+	LintError   = "error"
+)
+
+// LintMessage is a single finding of Lint
+type LintMessage struct {
+	Severity string
+	Path     string
+	Message  string
+}
+
+// Lint runs the helm lint checks on the chart, downloading it first if necessary. Findings of any severity are
+// returned as messages; the error is only set if the chart could not be linted at all
+func (c *HelmChartSrc) Lint() ([]LintMessage, error) {
+	if c.HelmChartOpts.Path == "" && !c.IsDownloaded() {
+		err := c.Download()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chartDir, err := c.ChartDir()
+	if err != nil {
+		return nil, err
+	}
+
+	lint := action.NewLint()
+	lint.Namespace = c.Namespace
+	if c.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(c.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		lint.KubeVersion = kubeVersion
+	}
+
+	result := lint.Run([]string{chartDir}, c.Values)
+	if result.TotalChartsLinted == 0 {
+		return nil, errors.Join(append(result.Errors, errors.New("failed to lint chart"))...)
+	}
+
+	messages := make([]LintMessage, 0, len(result.Messages))
+	for _, m := range result.Messages {
+		messages = append(messages, LintMessage{
+			Severity: lintSeverity(m.Severity),
+			Path:     m.Path,
+			Message:  m.Err.Error(),
+		})
+	}
+	return messages, nil
+}
+
+// HasLintErrors reports whether one of the messages has the severity error
+func HasLintErrors(messages []LintMessage) bool {
+	for _, m := range messages {
+		if m.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+func lintSeverity(severity int) string {
+	switch severity {
+	case support.InfoSev:
+		return LintInfo
+	case support.WarningSev:
+		return LintWarning
+	case support.ErrorSev:
+		return LintError
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,109 @@
+package helm
+
+import (
+	"errors"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// testHookAnnotation matches test-related Helm hook annotations (test, test-success, test-failure)
+var testHookAnnotation = regexp.MustCompile(`"?helm.sh/hook"?:.*test`)
+
+// Render renders the downloaded chart like `helm template` and returns the resulting YAML manifest
+// compare to https://github.com/pulumi/pulumi-kubernetes/blob/master/provider/pkg/provider/invoke_helm_template.go#L230
+func (c *HelmChartSrc) Render() (string, error) {
+	chartDir, err := c.ChartDir()
+	if err != nil {
+		return "", err
+	}
+
+	chart, err := loader.Load(chartDir)
+	if err != nil {
+		return "", errors.Join(err, errors.New("failed to load chart"))
+	}
+
+	registryClient, err := registry.NewClient(
+		registry.ClientOptDebug(c.HelmChartDebug),
+		registry.ClientOptCredentialsFile(c.HelmRegistryConfig),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	cfg := &action.Configuration{
+		Releases:       storage.Init(driver.NewMemory()),
+		RegistryClient: registryClient,
+	}
+
+	namespace := c.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	releaseName := c.ReleaseName
+	if releaseName == "" {
+		releaseName = chart.Name()
+	}
+
+	installAction := action.NewInstall(cfg)
+	installAction.ClientOnly = true
+	installAction.DryRun = true
+	installAction.IncludeCRDs = !c.SkipCRDRendering
+	installAction.Namespace = namespace
+	installAction.ReleaseName = releaseName
+	installAction.APIVersions = c.APIVersions
+
+	if c.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(c.KubeVersion)
+		if err != nil {
+			return "", err
+		}
+		installAction.KubeVersion = kubeVersion
+	}
+
+	rel, err := installAction.Run(chart, c.Values)
+	if err != nil {
+		return "", errors.Join(err, errors.New("failed to render chart"))
+	}
+
+	manifests := strings.Builder{}
+	manifests.WriteString(rel.Manifest)
+	for _, hook := range rel.Hooks {
+		if !c.IncludeTestHookResources && testHookAnnotation.MatchString(hook.Manifest) {
+			continue
+		}
+		manifests.WriteString("\n---\n")
+		manifests.WriteString(hook.Manifest)
+	}
+
+	return manifests.String(), nil
+}
+
+// ChartDir returns the directory the chart was extracted to; helm untars the chart into a subdirectory named after the chart
+func (c *HelmChartSrc) ChartDir() (string, error) {
+	if c.HelmChartOpts.Path != "" {
+		return c.HelmChartOpts.Path, nil
+	}
+
+	entries, err := os.ReadDir(c.Path())
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			return path.Join(c.Path(), entry.Name()), nil
+		}
+	}
+
+	return "", errors.New("no chart found in " + c.Path())
+}
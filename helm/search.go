@@ -0,0 +1,75 @@
+package helm
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/cmd/helm/search"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// searchThreshold is the maximum score of a search result, like in `helm search repo`
+const searchThreshold = 25
+
+// ChartResult is a chart found by RepoSearch
+type ChartResult struct {
+	Name        string
+	Version     string
+	Description string
+	AppVersion  string
+	Repo        string
+}
+
+// RepoSearch searches the cached indexes of all configured helm repositories for charts matching the query, like
+// `helm search repo`. An empty query returns all charts. A nil settings uses the helm defaults
+func RepoSearch(query string, settings *cli.EnvSettings) ([]ChartResult, error) {
+	if settings == nil {
+		settings = cli.New()
+	}
+
+	repoFile, err := repo.LoadFile(settings.RepositoryConfig)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("no repositories configured"))
+	}
+	if len(repoFile.Repositories) == 0 {
+		return nil, errors.New("no repositories configured")
+	}
+
+	index := search.NewIndex()
+	for _, entry := range repoFile.Repositories {
+		indexFile, err := repo.LoadIndexFile(filepath.Join(settings.RepositoryCache, helmpath.CacheIndexFile(entry.Name)))
+		if err != nil {
+			logrus.Warnf("repository %s is corrupt or missing, try 'helm repo update': %s", entry.Name, err)
+			continue
+		}
+		index.AddRepo(entry.Name, indexFile, false)
+	}
+
+	var results []*search.Result
+	if query == "" {
+		results = index.All()
+	} else {
+		results, err = index.Search(query, searchThreshold, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	search.SortScore(results)
+
+	charts := make([]ChartResult, 0, len(results))
+	for _, result := range results {
+		repoName, _, _ := strings.Cut(result.Name, "/")
+		charts = append(charts, ChartResult{
+			Name:        result.Chart.Name,
+			Version:     result.Chart.Version,
+			Description: result.Chart.Description,
+			AppVersion:  result.Chart.AppVersion,
+			Repo:        repoName,
+		})
+	}
+	return charts, nil
+}
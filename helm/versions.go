@@ -0,0 +1,124 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// ListVersions returns all available versions of the chart, newest first
+func (c *HelmChartSrc) ListVersions() ([]string, error) {
+	if registry.IsOCI(c.Chart) {
+		registryClient, err := registry.NewClient(
+			registry.ClientOptDebug(c.HelmChartDebug),
+			registry.ClientOptCredentialsFile(c.HelmRegistryConfig),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return registryClient.Tags(strings.TrimPrefix(c.Chart, fmt.Sprintf("%s://", registry.OCIScheme)))
+	}
+
+	settings := cli.New()
+
+	entry, err := c.repoEntry(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheDir, err := os.MkdirTemp("", "pulumi-helper-index")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(cacheDir)
+
+	chartRepo, err := repo.NewChartRepository(entry, getter.All(settings))
+	if err != nil {
+		return nil, err
+	}
+	chartRepo.CachePath = cacheDir
+
+	indexFile, err := chartRepo.DownloadIndexFile()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to download repository index"))
+	}
+
+	index, err := repo.LoadIndexFile(indexFile)
+	if err != nil {
+		return nil, err
+	}
+	index.SortEntries()
+
+	chartName := c.Chart
+	if c.Repo != "" {
+		chartName = strings.TrimPrefix(chartName, strings.TrimSuffix(c.Repo, "/")+"/")
+	}
+
+	chartVersions, ok := index.Entries[chartName]
+	if !ok {
+		return nil, fmt.Errorf("chart %s not found in %s", chartName, entry.URL)
+	}
+
+	versions := make([]string, 0, len(chartVersions))
+	for _, chartVersion := range chartVersions {
+		versions = append(versions, chartVersion.Version)
+	}
+	return versions, nil
+}
+
+// LatestVersion returns the newest version that is not a pre-release
+func LatestVersion(versions []string) (string, error) {
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			continue
+		}
+		if v.Prerelease() == "" {
+			return version, nil
+		}
+	}
+	return "", errors.New("no stable version found")
+}
+
+// repoEntry returns the repository of the chart: either the URL given in the fetch options or the named repository
+// from the helm repository config
+func (c *HelmChartSrc) repoEntry(settings *cli.EnvSettings) (*repo.Entry, error) {
+	if c.HelmFetchOpts.Repo != "" {
+		return &repo.Entry{
+			Name:     "pulumi-helper",
+			URL:      c.HelmFetchOpts.Repo,
+			Username: c.Username,
+			Password: c.Password,
+			CertFile: c.CertFile,
+			KeyFile:  c.KeyFile,
+			CAFile:   c.CAFile,
+		}, nil
+	}
+
+	repoName := c.Repo
+	if repoName == "" {
+		parts := strings.SplitN(c.Chart, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("no repository given for chart %s", c.Chart)
+		}
+		repoName = parts[0]
+	}
+
+	repoFile, err := repo.LoadFile(settings.RepositoryConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := repoFile.Get(repoName)
+	if entry == nil {
+		return nil, fmt.Errorf("repository %s not found in %s", repoName, settings.RepositoryConfig)
+	}
+	return entry, nil
+}
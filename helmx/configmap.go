@@ -0,0 +1,45 @@
+package helmx
+
+import (
+	"fmt"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+func configMap(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[*corev1.ConfigMap] {
+	c := chart.GetResource("v1/ConfigMap", fqn, namespace)
+
+	cm := c.ApplyT(func(c interface{}) *corev1.ConfigMap {
+		return c.(*corev1.ConfigMap)
+	})
+
+	b, err := pulumix.ConvertTyped[*corev1.ConfigMap](cm)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// ConfigMapData returns the data entry key of a ConfigMap. The output resolves to an error if the key is missing
+func ConfigMapData(chart *helmv3.Chart, fqn, namespace, key string) pulumix.Output[string] {
+	configMap := configMap(chart, fqn, namespace)
+
+	data := pulumix.ApplyErr(configMap, func(r *corev1.ConfigMap) (pulumix.Output[string], error) {
+		value := r.Data.ApplyT(func(data map[string]string) (string, error) {
+			v, ok := data[key]
+			if !ok {
+				return "", fmt.Errorf("key %s not found in configmap %s", key, fqn)
+			}
+			return v, nil
+		})
+
+		return pulumix.Output[string](pulumix.MustConvertTyped[string](value)), nil
+	})
+
+	d := pulumix.Flatten[string](data)
+
+	return d
+}
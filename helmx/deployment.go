@@ -0,0 +1,53 @@
+package helmx
+
+import (
+	"fmt"
+
+	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+func deployment(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[*appsv1.Deployment] {
+	d := chart.GetResource("apps/v1/Deployment", fqn, namespace)
+
+	dp := d.ApplyT(func(d interface{}) *appsv1.Deployment {
+		return d.(*appsv1.Deployment)
+	})
+
+	b, err := pulumix.ConvertTyped[*appsv1.Deployment](dp)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// DeploymentImage returns the image of the container named containerName or of the first container if it is empty
+func DeploymentImage(chart *helmv3.Chart, fqn, namespace, containerName string) pulumix.Output[string] {
+	deployment := deployment(chart, fqn, namespace)
+
+	image := pulumix.ApplyErr(deployment, func(r *appsv1.Deployment) (pulumix.Output[string], error) {
+		containers := r.Spec.Template().Spec().Containers()
+
+		img := containers.ApplyT(func(vs []corev1.Container) (string, error) {
+			for _, c := range vs {
+				if containerName != "" && c.Name != containerName {
+					continue
+				}
+				if c.Image == nil {
+					return "", fmt.Errorf("container %s has no image", c.Name)
+				}
+				return *c.Image, nil
+			}
+			return "", fmt.Errorf("container %q not found in deployment %s", containerName, fqn)
+		})
+
+		return pulumix.Output[string](pulumix.MustConvertTyped[string](img)), nil
+	})
+
+	i := pulumix.Flatten[string](image)
+
+	return i
+}
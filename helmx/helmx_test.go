@@ -0,0 +1,428 @@
+package helmx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mheers/pulumi-helper/mocks"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+	"github.com/stretchr/testify/require"
+)
+
+func demoChart(ctx *pulumi.Context) (*helmv3.Chart, error) {
+	return helmv3.NewChart(ctx, "demo", helmv3.ChartArgs{
+		Path:      pulumi.String("testdata/demo"),
+		Namespace: pulumi.String("demo"),
+	})
+}
+
+func ingressChart(ctx *pulumi.Context) (*helmv3.Chart, error) {
+	return helmv3.NewChart(ctx, "ingress", helmv3.ChartArgs{
+		Path:      pulumi.String("testdata/ingress"),
+		Namespace: pulumi.String("demo"),
+	})
+}
+
+func workloadsChart(ctx *pulumi.Context) (*helmv3.Chart, error) {
+	return helmv3.NewChart(ctx, "workloads", helmv3.ChartArgs{
+		Path:      pulumi.String("testdata/workloads"),
+		Namespace: pulumi.String("demo"),
+	})
+}
+
+func TestSecretData(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := demoChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		password := SecretData(chart, "demo-secret", "demo", "password")
+		pulumix.Apply(password, func(v string) string {
+			require.Equal(t, "s3cr3t", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestChartResources(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := demoChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		pulumix.Apply(ChartResources(chart, "v1/Secret", "demo"), func(rs []pulumi.Resource) int {
+			require.Len(t, rs, 1)
+			wg.Done()
+			return len(rs)
+		})
+
+		pulumix.Apply(ChartResources(chart, "apps/v1", "demo"), func(rs []pulumi.Resource) int {
+			require.Empty(t, rs)
+			wg.Done()
+			return len(rs)
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+// pendingIngressMocks creates ingresses without a load balancer status, like in a fresh cluster
+type pendingIngressMocks struct {
+	mocks.Mocks
+}
+
+func (m pendingIngressMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, state, err := m.Mocks.NewResource(args)
+	if args.TypeToken == "kubernetes:networking.k8s.io/v1:Ingress" {
+		state["status"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
+			"loadBalancer": map[string]interface{}{"ingress": []interface{}{}},
+		}))
+	}
+	return id, state, err
+}
+
+func TestWaitForIngressIP(t *testing.T) {
+	lookup := lookupIngressIP
+	t.Cleanup(func() { lookupIngressIP = lookup })
+	lookupIngressIP = func(ctx context.Context, namespace, name string) (string, error) {
+		t.Error("the live ingress must not be looked up if the chart's ingress has an IP")
+		return "", nil
+	}
+
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := ingressChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		ip := WaitForIngressIP(waitCtx, chart, "demo-ingress", "demo", 100*time.Millisecond)
+		pulumix.Apply(ip, func(v string) string {
+			require.Equal(t, "10.0.0.1", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestWaitForIngressIPPolls(t *testing.T) {
+	lookup := lookupIngressIP
+	t.Cleanup(func() { lookupIngressIP = lookup })
+	var lookups []string
+	lookupIngressIP = func(ctx context.Context, namespace, name string) (string, error) {
+		lookups = append(lookups, namespace+"/"+name)
+		if len(lookups) == 1 {
+			return "", nil
+		}
+		return "10.0.0.3", nil
+	}
+
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := ingressChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		ip := WaitForIngressIP(waitCtx, chart, "demo-ingress", "demo", 10*time.Millisecond)
+		pulumix.Apply(ip, func(v string) string {
+			require.Equal(t, "10.0.0.3", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", pendingIngressMocks{}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"demo/demo-ingress", "demo/demo-ingress"}, lookups)
+}
+
+func TestAllIngressIPs(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := ingressChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		ips := AllIngressIPs(chart, "demo-ingress", "demo")
+		pulumix.Apply(ips, func(v []string) []string {
+			require.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestServicePorts(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := ingressChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		all := ServicePorts(chart, "demo", "demo")
+		pulumix.Apply(all, func(v []ServicePort) []ServicePort {
+			require.Equal(t, []ServicePort{
+				{Name: "http", Protocol: "TCP", Port: 80, TargetPort: 8080},
+				{Name: "dns", Protocol: "UDP", Port: 53, TargetPort: 53},
+			}, v)
+			wg.Done()
+			return v
+		})
+
+		udp := ServicePorts(chart, "demo", "demo", "UDP")
+		pulumix.Apply(udp, func(v []ServicePort) []ServicePort {
+			require.Equal(t, []ServicePort{{Name: "dns", Protocol: "UDP", Port: 53, TargetPort: 53}}, v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestIntOrString(t *testing.T) {
+	for _, v := range []interface{}{8080, 8080.0, "8080"} {
+		got, err := intOrString(v)
+		require.NoError(t, err)
+		require.Equal(t, 8080, got)
+	}
+
+	got, err := intOrString("http")
+	require.NoError(t, err)
+	require.Equal(t, 0, got)
+
+	_, err = intOrString(true)
+	require.Error(t, err)
+}
+
+func TestChartResourcesKindBoundary(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := ingressChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		pulumix.Apply(ChartResources(chart, "v1/Service", "demo"), func(rs []pulumi.Resource) int {
+			require.Len(t, rs, 1)
+			wg.Done()
+			return len(rs)
+		})
+
+		pulumix.Apply(ChartResources(chart, "v1/ServiceAccount", "demo"), func(rs []pulumi.Resource) int {
+			require.Len(t, rs, 1)
+			wg.Done()
+			return len(rs)
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestMatchesGVK(t *testing.T) {
+	require.True(t, matchesGVK("v1/Service::demo/web", "v1/Service"))
+	require.True(t, matchesGVK("apps/v1/Deployment::demo/web", "apps/v1"))
+	require.False(t, matchesGVK("v1/ServiceAccount::demo/web", "v1/Service"))
+	require.False(t, matchesGVK("apps/v1beta1/Deployment::demo/web", "apps/v1"))
+}
+
+func TestServiceClusterIP(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := ingressChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		ip := ServiceClusterIP(chart, "demo", "demo")
+		pulumix.Apply(ip, func(v string) string {
+			require.Equal(t, "10.96.0.10", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestServiceClusterIPHeadless(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := workloadsChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = internals.UnsafeAwaitOutput(ctx.Context(), ServiceClusterIP(chart, "db", "demo"))
+		require.ErrorContains(t, err, "service db is headless")
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestDeploymentImage(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := workloadsChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		first := DeploymentImage(chart, "web", "demo", "")
+		pulumix.Apply(first, func(v string) string {
+			require.Equal(t, "nginx:1.25", v)
+			wg.Done()
+			return v
+		})
+
+		sidecar := DeploymentImage(chart, "web", "demo", "sidecar")
+		pulumix.Apply(sidecar, func(v string) string {
+			require.Equal(t, "busybox:1.36", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestConfigMapData(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := workloadsChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		data := ConfigMapData(chart, "web-config", "demo", "server.conf")
+		pulumix.Apply(data, func(v string) string {
+			require.Equal(t, "listen 80;", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestConfigMapDataMissingKey(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := workloadsChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = internals.UnsafeAwaitOutput(ctx.Context(), ConfigMapData(chart, "web-config", "demo", "missing.conf"))
+		require.ErrorContains(t, err, "key missing.conf not found in configmap web-config")
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestStatefulSetStorageSize(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		chart, err := workloadsChart(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		size := StatefulSetStorageSize(chart, "db", "demo", "data")
+		pulumix.Apply(size, func(v string) string {
+			require.Equal(t, "10Gi", v)
+			wg.Done()
+			return v
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestInNamespace(t *testing.T) {
+	require.True(t, inNamespace("demo/cm", "demo"))
+	require.False(t, inNamespace("other/cm", "demo"))
+	require.True(t, inNamespace("cm", ""))
+	require.True(t, inNamespace("default/cm", "default"))
+	require.False(t, inNamespace("demo/cm", ""))
+}
@@ -1,11 +1,16 @@
 package helmx
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
 	networkingv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/networking/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func ingress(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[*networkingv1.Ingress] {
@@ -48,3 +53,101 @@ func IngressIP(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[string
 
 	return fIP
 }
+
+// ingressIPOrEmpty returns the first load balancer IP of the ingress or an empty string if none is assigned yet
+func ingressIPOrEmpty(r *networkingv1.Ingress) pulumix.Output[string] {
+	lbiao := r.Status.LoadBalancer().Ingress().ToIngressLoadBalancerIngressArrayOutput()
+
+	ip := lbiao.ApplyT(func(vs []networkingv1.IngressLoadBalancerIngress) string {
+		if len(vs) == 0 || vs[0].Ip == nil {
+			return ""
+		}
+		return *vs[0].Ip
+	})
+
+	return pulumix.MustConvertTyped[string](ip)
+}
+
+// WaitForIngressIP returns the load balancer IP of the ingress. If the chart's ingress has none assigned yet, the live
+// ingress is looked up in the cluster every interval until it has an IP or the context is done
+func WaitForIngressIP(ctx context.Context, chart *helmv3.Chart, fqn, namespace string, interval time.Duration) pulumix.Output[string] {
+	frontendIP := pulumix.ApplyErr(ingress(chart, fqn, namespace), func(r *networkingv1.Ingress) (pulumix.Output[string], error) {
+		ip := pulumix.ApplyErr(ingressIPOrEmpty(r), func(ip string) (string, error) {
+			if ip != "" {
+				return ip, nil
+			}
+			return pollIngressIP(ctx, namespace, fqn, interval)
+		})
+		return ip, nil
+	})
+
+	return pulumix.Flatten[string](frontendIP)
+}
+
+func pollIngressIP(ctx context.Context, namespace, name string, interval time.Duration) (string, error) {
+	for {
+		ip, err := lookupIngressIP(ctx, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		if ip != "" {
+			return ip, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// lookupIngressIP returns the first load balancer IP of the live ingress or an empty string if none is assigned yet.
+// It reads the ingress with the default kubeconfig, so polling does not register any resources in the stack
+var lookupIngressIP = func(ctx context.Context, namespace, name string) (string, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return "", err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", err
+	}
+
+	ing, err := clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			return lb.IP, nil
+		}
+	}
+	return "", nil
+}
+
+// AllIngressIPs returns the IPs of all load balancer entries of the ingress
+func AllIngressIPs(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[[]string] {
+	ingress := ingress(chart, fqn, namespace)
+
+	frontendIPs := pulumix.ApplyErr(ingress, func(r *networkingv1.Ingress) (pulumix.Output[[]string], error) {
+		lbiao := r.Status.LoadBalancer().Ingress().ToIngressLoadBalancerIngressArrayOutput()
+
+		ips := lbiao.ApplyT(func(vs []networkingv1.IngressLoadBalancerIngress) []string {
+			ips := []string{}
+			for _, v := range vs {
+				if v.Ip != nil {
+					ips = append(ips, *v.Ip)
+				}
+			}
+			return ips
+		})
+
+		return pulumix.Output[[]string](pulumix.MustConvertTyped[[]string](ips)), nil
+	})
+
+	return pulumix.Flatten[[]string](frontendIPs)
+}
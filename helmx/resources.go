@@ -0,0 +1,58 @@
+package helmx
+
+import (
+	"sort"
+	"strings"
+
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+// ChartResources returns all resources of the chart whose group/version/kind starts with gvk, e.g. "apps/v1" or
+// "v1/ConfigMap", and that live in namespace. They are sorted by their key in the chart's resource map
+func ChartResources(chart *helmv3.Chart, gvk, namespace string) pulumix.Output[[]pulumi.Resource] {
+	r := chart.Resources.ApplyT(func(x interface{}) []pulumi.Resource {
+		resources := x.(map[string]pulumi.Resource)
+
+		keys := []string{}
+		for key := range resources {
+			if !matchesGVK(key, gvk) {
+				continue
+			}
+			_, id, found := strings.Cut(key, "::")
+			if !found || !inNamespace(id, namespace) {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		result := make([]pulumi.Resource, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, resources[key])
+		}
+		return result
+	})
+
+	return pulumix.MustConvertTyped[[]pulumi.Resource](r)
+}
+
+// matchesGVK reports whether the chart resource key ("group/version/kind::id") starts with gvk at a "/" or "::"
+// boundary, so "v1/Service" does not match "v1/ServiceAccount::..."
+func matchesGVK(key, gvk string) bool {
+	rest, found := strings.CutPrefix(key, gvk)
+	if !found {
+		return false
+	}
+	return rest == "" || strings.HasPrefix(rest, "/") || strings.HasPrefix(rest, "::")
+}
+
+// inNamespace reports whether a chart resource id ("name" or "namespace/name") belongs to namespace
+func inNamespace(id, namespace string) bool {
+	ns, _, namespaced := strings.Cut(id, "/")
+	if namespace == "" || namespace == "default" {
+		return !namespaced || ns == "default"
+	}
+	return namespaced && ns == namespace
+}
@@ -0,0 +1,51 @@
+package helmx
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+func secret(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[*corev1.Secret] {
+	s := chart.GetResource("v1/Secret", fqn, namespace)
+
+	sc := s.ApplyT(func(s interface{}) *corev1.Secret {
+		return s.(*corev1.Secret)
+	})
+
+	b, err := pulumix.ConvertTyped[*corev1.Secret](sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// SecretData returns the base64 decoded data entry key of a Secret as a Pulumi secret
+func SecretData(chart *helmv3.Chart, fqn, namespace, key string) pulumix.Output[string] {
+	secret := secret(chart, fqn, namespace)
+
+	data := pulumix.ApplyErr(secret, func(r *corev1.Secret) (pulumix.Output[string], error) {
+		value := r.Data.ApplyT(func(data map[string]string) (string, error) {
+			v, ok := data[key]
+			if !ok {
+				return "", fmt.Errorf("key %s not found in secret %s", key, fqn)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return "", err
+			}
+			return string(decoded), nil
+		})
+
+		return pulumix.Output[string](pulumix.MustConvertTyped[string](pulumi.ToSecret(value))), nil
+	})
+
+	d := pulumix.Flatten[string](data)
+
+	return d
+}
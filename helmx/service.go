@@ -0,0 +1,121 @@
+package helmx
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+func service(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[*corev1.Service] {
+	s := chart.GetResource("v1/Service", fqn, namespace)
+
+	sv := s.ApplyT(func(s interface{}) *corev1.Service {
+		return s.(*corev1.Service)
+	})
+
+	b, err := pulumix.ConvertTyped[*corev1.Service](sv)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func ServiceClusterIP(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[string] {
+	service := service(chart, fqn, namespace)
+
+	clusterIP := pulumix.ApplyErr(service, func(r *corev1.Service) (pulumix.Output[string], error) {
+		spec := r.Spec
+
+		ip := spec.ClusterIP().ApplyT(func(ip *string) (string, error) {
+			if ip == nil || *ip == "" {
+				return "", fmt.Errorf("service %s has no cluster ip", fqn)
+			}
+			if *ip == "None" {
+				return "", fmt.Errorf("service %s is headless", fqn)
+			}
+			return *ip, nil
+		})
+
+		return pulumix.Output[string](pulumix.MustConvertTyped[string](ip)), nil
+	})
+
+	cIP := pulumix.Flatten[string](clusterIP)
+
+	return cIP
+}
+
+// ServicePort is a port exposed by a service
+type ServicePort struct {
+	Name       string
+	Protocol   string
+	Port       int
+	TargetPort int
+}
+
+// ServicePorts returns the ports of the service, optionally filtered by protocols (e.g. "TCP", "UDP")
+func ServicePorts(chart *helmv3.Chart, fqn, namespace string, protocols ...string) pulumix.Output[[]ServicePort] {
+	service := service(chart, fqn, namespace)
+
+	ports := pulumix.ApplyErr(service, func(r *corev1.Service) (pulumix.Output[[]ServicePort], error) {
+		spec := r.Spec
+
+		// []ServicePort has no registered output type, so the typed pulumix apply is used instead of ApplyT
+		specPorts := pulumix.MustConvertTyped[[]corev1.ServicePort](spec.Ports())
+
+		ps := pulumix.ApplyErr(specPorts, func(vs []corev1.ServicePort) ([]ServicePort, error) {
+			ports := []ServicePort{}
+			for _, v := range vs {
+				port := ServicePort{
+					Protocol:   "TCP",
+					Port:       v.Port,
+					TargetPort: v.Port,
+				}
+				if v.Name != nil {
+					port.Name = *v.Name
+				}
+				if v.Protocol != nil {
+					port.Protocol = *v.Protocol
+				}
+				if len(protocols) > 0 && !slices.Contains(protocols, port.Protocol) {
+					continue
+				}
+				if v.TargetPort != nil {
+					targetPort, err := intOrString(v.TargetPort)
+					if err != nil {
+						return nil, fmt.Errorf("service %s port %d: %w", fqn, v.Port, err)
+					}
+					port.TargetPort = targetPort
+				}
+				ports = append(ports, port)
+			}
+			return ports, nil
+		})
+
+		return ps, nil
+	})
+
+	return pulumix.Flatten[[]ServicePort](ports)
+}
+
+// intOrString converts an IntOrString value to an int; named ports resolve to 0 as they can only be looked up in the pods
+func intOrString(v interface{}) (int, error) {
+	switch t := v.(type) {
+	case int:
+		return t, nil
+	case float64:
+		return int(t), nil
+	case string:
+		i, err := strconv.Atoi(t)
+		if err != nil {
+			return 0, nil
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("unsupported target port type %T", v)
+	}
+}
@@ -0,0 +1,57 @@
+package helmx
+
+import (
+	"fmt"
+
+	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+func statefulSet(chart *helmv3.Chart, fqn, namespace string) pulumix.Output[*appsv1.StatefulSet] {
+	s := chart.GetResource("apps/v1/StatefulSet", fqn, namespace)
+
+	ss := s.ApplyT(func(s interface{}) *appsv1.StatefulSet {
+		return s.(*appsv1.StatefulSet)
+	})
+
+	b, err := pulumix.ConvertTyped[*appsv1.StatefulSet](ss)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// StatefulSetStorageSize returns the requested storage of the volume claim template named claimName
+func StatefulSetStorageSize(chart *helmv3.Chart, fqn, namespace, claimName string) pulumix.Output[string] {
+	statefulSet := statefulSet(chart, fqn, namespace)
+
+	storage := pulumix.ApplyErr(statefulSet, func(r *appsv1.StatefulSet) (pulumix.Output[string], error) {
+		claims := r.Spec.VolumeClaimTemplates()
+
+		size := claims.ApplyT(func(vs []corev1.PersistentVolumeClaimType) (string, error) {
+			for _, claim := range vs {
+				if claim.Metadata == nil || claim.Metadata.Name == nil || *claim.Metadata.Name != claimName {
+					continue
+				}
+				if claim.Spec == nil || claim.Spec.Resources == nil {
+					return "", fmt.Errorf("volume claim template %s has no resources", claimName)
+				}
+				size, ok := claim.Spec.Resources.Requests["storage"]
+				if !ok {
+					return "", fmt.Errorf("volume claim template %s requests no storage", claimName)
+				}
+				return size, nil
+			}
+			return "", fmt.Errorf("volume claim template %s not found in statefulset %s", claimName, fqn)
+		})
+
+		return pulumix.Output[string](pulumix.MustConvertTyped[string](size)), nil
+	})
+
+	s := pulumix.Flatten[string](storage)
+
+	return s
+}
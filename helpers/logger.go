@@ -1,35 +1,104 @@
 package helpers
 
-import "github.com/sirupsen/logrus"
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
 
 // SetLogLevel sets the log level for logrus by string; possible values are debug, error, fatal, panic, info, trace
 func SetLogLevel(loglevelString string) {
-	loglevel := logrus.ErrorLevel
+	loglevel := parseLogLevel(loglevelString)
+	logrus.SetLevel(loglevel)
+	logrus.Debugf("LogLevel: %s", loglevel.String())
+
+	logrus.SetFormatter(&logrus.TextFormatter{
+		ForceColors:               true,
+		FullTimestamp:             true,
+		QuoteEmptyFields:          true,
+		EnvironmentOverrideColors: true,
+	})
+}
+
+func parseLogLevel(loglevelString string) logrus.Level {
 	switch loglevelString {
 	case "":
-		loglevel = logrus.ErrorLevel
+		return logrus.ErrorLevel
 	case "error":
-		loglevel = logrus.ErrorLevel
+		return logrus.ErrorLevel
 	case "debug":
-		loglevel = logrus.DebugLevel
+		return logrus.DebugLevel
 	case "fatal":
-		loglevel = logrus.FatalLevel
+		return logrus.FatalLevel
 	case "panic":
-		loglevel = logrus.PanicLevel
+		return logrus.PanicLevel
 	case "info":
-		loglevel = logrus.InfoLevel
+		return logrus.InfoLevel
 	case "trace":
-		loglevel = logrus.TraceLevel
+		return logrus.TraceLevel
 	default:
-		loglevel = logrus.ErrorLevel
+		return logrus.ErrorLevel
 	}
+}
+
+// SetupSlogLogger creates a slog.Logger writing to stderr, makes it the default slog logger and forwards all logrus
+// entries to it, so both are controlled by the same level
+func SetupSlogLogger(loglevelString string) *slog.Logger {
+	loglevel := parseLogLevel(loglevelString)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slogLevel(loglevel),
+	}))
+	slog.SetDefault(logger)
+
 	logrus.SetLevel(loglevel)
-	logrus.Debugf("LogLevel: %s", loglevel.String())
+	logrus.SetOutput(io.Discard)
+	logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	logrus.AddHook(&slogHook{logger: logger})
 
-	logrus.SetFormatter(&logrus.TextFormatter{
-		ForceColors:               true,
-		FullTimestamp:             true,
-		QuoteEmptyFields:          true,
-		EnvironmentOverrideColors: true,
-	})
+	return logger
+}
+
+// slogLevel maps a logrus level to a slog level; trace is below debug, fatal and panic are above error
+func slogLevel(level logrus.Level) slog.Level {
+	switch level {
+	case logrus.TraceLevel:
+		return slog.LevelDebug - 4
+	case logrus.DebugLevel:
+		return slog.LevelDebug
+	case logrus.InfoLevel:
+		return slog.LevelInfo
+	case logrus.WarnLevel:
+		return slog.LevelWarn
+	case logrus.ErrorLevel:
+		return slog.LevelError
+	default:
+		return slog.LevelError + 4
+	}
+}
+
+// slogHook is a logrus hook that writes the entries to a slog.Logger
+type slogHook struct {
+	logger *slog.Logger
+}
+
+func (h *slogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *slogHook) Fire(entry *logrus.Entry) error {
+	attrs := make([]slog.Attr, 0, len(entry.Data))
+	for key, value := range entry.Data {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	h.logger.LogAttrs(ctx, slogLevel(entry.Level), entry.Message, attrs...)
+	return nil
 }
@@ -1,8 +1,16 @@
 package helpers
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/common-nighthawk/go-figure"
 	"github.com/gocarina/gocsv"
@@ -43,3 +51,137 @@ func PrintCSV(obj interface{}) error {
 	fmt.Println(string(csv))
 	return nil
 }
+
+// PrintXML prints obj as indented XML below a <result> root element. Slice elements become <item> elements and map
+// keys that are no valid XML names become <entry key="..."> elements
+func PrintXML(obj interface{}) error {
+	// convert via JSON so maps, pointers and embedded structs are handled like in PrintJSON
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	err = json.Unmarshal(b, &generic)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(os.Stdout)
+	enc.Indent("", "  ")
+	err = encodeXML(enc, xml.StartElement{Name: xml.Name{Local: "result"}}, generic)
+	if err != nil {
+		return err
+	}
+	err = enc.Flush()
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+var xmlNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+func encodeXML(enc *xml.Encoder, start xml.StartElement, value interface{}) error {
+	err := enc.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := xml.StartElement{Name: xml.Name{Local: key}}
+			if !xmlNameRegexp.MatchString(key) {
+				child = xml.StartElement{
+					Name: xml.Name{Local: "entry"},
+					Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+				}
+			}
+			err = encodeXML(enc, child, v[key])
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			err = encodeXML(enc, xml.StartElement{Name: xml.Name{Local: "item"}}, item)
+			if err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		err = enc.EncodeToken(xml.CharData(fmt.Sprint(v)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// PrintMarkdown prints obj as a GitHub flavored markdown pipe table using the same columns as PrintCSV
+func PrintMarkdown(obj interface{}) error {
+	b, err := gocsv.MarshalBytes(obj)
+	if err != nil {
+		return err
+	}
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	fmt.Println(markdownRow(records[0]))
+	separator := make([]string, len(records[0]))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	fmt.Println(markdownRow(separator))
+	for _, record := range records[1:] {
+		fmt.Println(markdownRow(record))
+	}
+	return nil
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", "\\|")
+		escaped[i] = strings.ReplaceAll(cell, "\n", "<br>")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// PrintDotenv prints values as KEY=value lines sorted by key. Keys are uppercased and ":" is replaced with "_"
+func PrintDotenv(values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", DotenvKey(key), dotenvValue(values[key]))
+	}
+}
+
+// DotenvKey converts a config key like "project:db-host" to "PROJECT_DB-HOST"
+func DotenvKey(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ":", "_"))
+}
+
+func dotenvValue(value string) string {
+	if strings.ContainsAny(value, " \t\n\"'#$") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// TableOpts configures the rendering of PrintTable
+type TableOpts struct {
+	// MaxColumnWidth wraps cells that are wider than this; 0 means unlimited
+	MaxColumnWidth int
+	// SortColumn is the header of the column to sort the rows by; empty keeps the order of the rows
+	SortColumn string
+	// SortDescending sorts the rows in descending instead of ascending order
+	SortDescending bool
+	// RowSeparators prints a separator line between the rows
+	RowSeparators bool
+	// Colored uses the colored style instead of the default one
+	Colored bool
+	// RowColors returns the colors of a row, e.g. to highlight changes; nil keeps the colors of the style
+	RowColors func(row []string) text.Colors
+}
+
+// PrintTable prints the rows as a table with the given headers to stdout
+func PrintTable(headers []string, rows [][]string, opts TableOpts) error {
+	for i, row := range rows {
+		if len(row) != len(headers) {
+			return fmt.Errorf("row %d has %d columns, expected %d", i, len(row), len(headers))
+		}
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	if opts.Colored {
+		t.SetStyle(table.StyleColoredBright)
+	}
+
+	header := make(table.Row, 0, len(headers))
+	for _, h := range headers {
+		header = append(header, h)
+	}
+	t.AppendHeader(header)
+
+	if opts.MaxColumnWidth > 0 {
+		configs := make([]table.ColumnConfig, 0, len(headers))
+		for i := range headers {
+			configs = append(configs, table.ColumnConfig{Number: i + 1, WidthMax: opts.MaxColumnWidth})
+		}
+		t.SetColumnConfigs(configs)
+	}
+
+	if opts.SortColumn != "" {
+		if !slices.Contains(headers, opts.SortColumn) {
+			return fmt.Errorf("unknown sort column %q", opts.SortColumn)
+		}
+		mode := table.Asc
+		if opts.SortDescending {
+			mode = table.Dsc
+		}
+		t.SortBy([]table.SortBy{{Name: opts.SortColumn, Mode: mode}})
+	}
+
+	if opts.RowColors != nil {
+		t.SetRowPainter(func(row table.Row) text.Colors {
+			cells := make([]string, 0, len(row))
+			for _, cell := range row {
+				cells = append(cells, fmt.Sprint(cell))
+			}
+			return opts.RowColors(cells)
+		})
+	}
+
+	for _, row := range rows {
+		r := make(table.Row, 0, len(row))
+		for _, cell := range row {
+			r = append(r, cell)
+		}
+		t.AppendRow(r)
+	}
+	t.Style().Options.SeparateRows = opts.RowSeparators
+
+	t.Render()
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package kms provides stack.SecretsProvider implementations that encrypt stack config values with a cloud key
+// management service
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gocloud.dev/secrets"
+	_ "gocloud.dev/secrets/awskms"
+	_ "gocloud.dev/secrets/gcpkms"
+)
+
+// Provider encrypts and decrypts values with a key of a key management service. The ciphertexts are base64 encoded
+type Provider struct {
+	keeper *secrets.Keeper
+}
+
+// NewKMSProvider returns a Provider using the AWS KMS key keyARN. The region is taken from the ARN; for key ids and
+// aliases the region of the AWS environment is used
+func NewKMSProvider(keyARN string) (*Provider, error) {
+	return openProvider(awsKMSURL(keyARN))
+}
+
+// NewGCPKMSProvider returns a Provider using the GCP Cloud KMS key
+// projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>
+func NewGCPKMSProvider(resourceName string) (*Provider, error) {
+	return openProvider(gcpKMSURL(resourceName))
+}
+
+func openProvider(keeperURL string) (*Provider, error) {
+	keeper, err := secrets.OpenKeeper(context.Background(), keeperURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not open key %s: %w", keeperURL, err)
+	}
+	return &Provider{keeper: keeper}, nil
+}
+
+func (p *Provider) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	ciphertext, err := p.keeper.Encrypt(ctx, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (p *Provider) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := p.keeper.Decrypt(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Close releases the connection to the key management service
+func (p *Provider) Close() error {
+	return p.keeper.Close()
+}
+
+func awsKMSURL(keyARN string) string {
+	u := "awskms:///" + keyARN
+	// arn:<partition>:kms:<region>:<account>:key/<id>
+	if parts := strings.Split(keyARN, ":"); len(parts) == 6 && parts[0] == "arn" {
+		u += "?" + url.Values{"region": {parts[3]}}.Encode()
+	}
+	return u
+}
+
+func gcpKMSURL(resourceName string) string {
+	return "gcpkms://" + resourceName
+}
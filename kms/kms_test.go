@@ -0,0 +1,60 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/mheers/pulumi-helper/stack"
+	"github.com/stretchr/testify/require"
+	_ "gocloud.dev/secrets/localsecrets"
+)
+
+var _ stack.SecretsProvider = (*Provider)(nil)
+
+func TestURLs(t *testing.T) {
+	require.Equal(t,
+		"awskms:///arn:aws:kms:eu-central-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab?region=eu-central-1",
+		awsKMSURL("arn:aws:kms:eu-central-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+	)
+	require.Equal(t, "awskms:///alias/pulumi", awsKMSURL("alias/pulumi"))
+	require.Equal(t,
+		"gcpkms://projects/demo/locations/global/keyRings/pulumi/cryptoKeys/stacks",
+		gcpKMSURL("projects/demo/locations/global/keyRings/pulumi/cryptoKeys/stacks"),
+	)
+}
+
+func TestProvider(t *testing.T) {
+	// the local base64key keeper stands in for a cloud key management service
+	key := "base64key://" + base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+	provider, err := openProvider(key)
+	require.NoError(t, err)
+	defer provider.Close()
+
+	encrypted, err := provider.Encrypt(context.Background(), "test")
+	require.NoError(t, err)
+	require.NotEqual(t, "test", encrypted)
+
+	decrypted, err := provider.Decrypt(context.Background(), encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "test", decrypted)
+
+	_, err = provider.Decrypt(context.Background(), "not base64")
+	require.Error(t, err)
+}
+
+func TestRegisterProvider(t *testing.T) {
+	key := "base64key://" + base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	provider, err := openProvider(key)
+	require.NoError(t, err)
+	defer provider.Close()
+
+	stack.RegisterSecretsProvider(provider)
+
+	encrypted, err := stack.Encrypt("test")
+	require.NoError(t, err)
+	decrypted, err := stack.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "test", decrypted)
+}
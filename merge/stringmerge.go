@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
@@ -81,3 +82,72 @@ func values(sm pulumi.MapArray, key string) []string {
 	wg.Wait()
 	return values
 }
+
+// DeduplicateStrings removes duplicate values within each group. The first occurrence is kept
+func (sma StringMergeArray) DeduplicateStrings() StringMergeArray {
+	result := make(StringMergeArray, 0, len(sma))
+	for _, sm := range sma {
+		seen := map[string]bool{}
+		values := []string{}
+		for _, v := range sm.Values {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			values = append(values, v)
+		}
+		result = append(result, StringMerge{
+			Key:    sm.Key,
+			Values: values,
+		})
+	}
+	return result
+}
+
+// Filter keeps only the groups for which keep returns true
+func (sma StringMergeArray) Filter(keep func(key string, values []string) bool) StringMergeArray {
+	result := StringMergeArray{}
+	for _, sm := range sma {
+		if keep(awaitKey(sm.Key), sm.Values) {
+			result = append(result, sm)
+		}
+	}
+	return result
+}
+
+// SortByKeyAlpha returns the groups sorted alphabetically by their key
+func (sma StringMergeArray) SortByKeyAlpha() StringMergeArray {
+	keys := make([]string, len(sma))
+	for i, sm := range sma {
+		keys[i] = awaitKey(sm.Key)
+	}
+
+	indices := make([]int, len(sma))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return keys[indices[i]] < keys[indices[j]]
+	})
+
+	result := make(StringMergeArray, 0, len(sma))
+	for _, i := range indices {
+		result = append(result, sma[i])
+	}
+	return result
+}
+
+func awaitKey(key pulumi.StringPtrOutput) string {
+	var result string
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	key.ApplyT(func(k *string) string {
+		if k != nil {
+			result = *k
+		}
+		wg.Done()
+		return result
+	})
+	wg.Wait()
+	return result
+}
@@ -75,3 +75,58 @@ func TestMergeHostAliases(t *testing.T) {
 	assert.True(t, len(got) == 1)
 	assert.True(t, len(got[0].HostNames) == 2)
 }
+
+func TestStringMergeArrayDeduplicateStrings(t *testing.T) {
+	alias1 := StringMerge{
+		Key:    pulumi.String("192.168.0.1").ToStringPtrOutput(),
+		Values: []string{"hostname1"},
+	}
+	alias2 := StringMerge{
+		Key:    pulumi.String("192.168.0.1").ToStringPtrOutput(),
+		Values: []string{"hostname1", "hostname2"},
+	}
+
+	got := StringMergeToStringMergeArray(alias1, alias2).Merge().DeduplicateStrings()
+
+	assert.True(t, len(got) == 1)
+	assert.ElementsMatch(t, []string{"hostname1", "hostname2"}, got[0].Values)
+}
+
+func TestStringMergeArrayFilter(t *testing.T) {
+	alias1 := StringMerge{
+		Key:    pulumi.String("192.168.0.1").ToStringPtrOutput(),
+		Values: []string{"hostname1"},
+	}
+	alias2 := StringMerge{
+		Key:    pulumi.String("192.168.0.1").ToStringPtrOutput(),
+		Values: []string{"hostname2"},
+	}
+	alias3 := StringMerge{
+		Key:    pulumi.String("192.168.0.2").ToStringPtrOutput(),
+		Values: []string{"hostname3"},
+	}
+
+	got := StringMergeToStringMergeArray(alias1, alias2, alias3).Merge().Filter(func(key string, values []string) bool {
+		return key == "192.168.0.2"
+	})
+
+	assert.True(t, len(got) == 1)
+	assert.Equal(t, []string{"hostname3"}, got[0].Values)
+}
+
+func TestStringMergeArraySortByKeyAlpha(t *testing.T) {
+	aliases := []StringMerge{
+		{Key: pulumi.String("192.168.0.3").ToStringPtrOutput(), Values: []string{"hostname3"}},
+		{Key: pulumi.String("192.168.0.1").ToStringPtrOutput(), Values: []string{"hostname1"}},
+		{Key: pulumi.String("192.168.0.2").ToStringPtrOutput(), Values: []string{"hostname2"}},
+	}
+
+	for i := 0; i < 5; i++ {
+		got := StringMergeToStringMergeArray(aliases...).Merge().SortByKeyAlpha()
+
+		assert.True(t, len(got) == 3)
+		assert.Equal(t, []string{"hostname1"}, got[0].Values)
+		assert.Equal(t, []string{"hostname2"}, got[1].Values)
+		assert.Equal(t, []string{"hostname3"}, got[2].Values)
+	}
+}
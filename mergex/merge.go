@@ -1,14 +1,15 @@
 package helpers
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
 )
 
 type allowedKeyType interface {
-	// string | int | float64 | bool | *string | *int | *float64 | *bool | chan string | chan int | chan float64 | chan bool // TODO
-	*string | *int | *float64 | *bool
+	// chan string | chan int | chan float64 | chan bool // TODO
+	string | int | float64 | bool | *string | *int | *float64 | *bool
 }
 
 type Merge[K allowedKeyType, V any] struct {
@@ -22,70 +23,161 @@ func MergeToMergeArray[K allowedKeyType, V any](s ...Merge[K, V]) MergeArray[K,
 	return s
 }
 
+// Merge combines the values of all entries with the same key. Entries without a key are skipped; pointer keys are
+// compared by the value they point to
 func (sma MergeArray[K, V]) Merge() MergeArray[K, V] {
 	result := MergeArray[K, V]{}
+	handledKeys := map[any]int{}
 
-	sm := pulumix.Array[pulumix.Map[any]]{}
 	for _, x := range sma {
-		m := pulumix.Map[any]{
-			"key":    x.Key.AsAny(),
-			"values": pulumix.Val[[]V](x.Values).AsAny(),
+		key := awaitKey(x.Key)
+		id, ok := keyID(key)
+		if !ok {
+			continue
 		}
-		sm = append(sm, pulumix.Val[pulumix.Map[any]](m))
+		if i, handled := handledKeys[id]; handled {
+			result[i].Values = append(result[i].Values, x.Values...)
+			continue
+		}
+		handledKeys[id] = len(result)
+
+		result = append(result, Merge[K, V]{
+			Key:    pulumix.Val[K](key),
+			Values: append([]V{}, x.Values...),
+		})
 	}
+	return result
+}
 
-	handledKeys := map[K]bool{}
+// keyID returns a comparable identity of the key, dereferencing pointer keys. It returns false only for nil pointer
+// keys; zero values of plain keys (false, 0, "") are valid keys
+func keyID[K allowedKeyType](key K) (any, bool) {
+	switch k := any(key).(type) {
+	case *string:
+		if k == nil {
+			return nil, false
+		}
+		return *k, true
+	case *int:
+		if k == nil {
+			return nil, false
+		}
+		return *k, true
+	case *float64:
+		if k == nil {
+			return nil, false
+		}
+		return *k, true
+	case *bool:
+		if k == nil {
+			return nil, false
+		}
+		return *k, true
+	default:
+		return k, true
+	}
+}
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	sm.AsAny().ApplyT(func(m any) any {
-		mi := m.([]pulumix.Map[any])
-		for _, mx := range mi {
-			kk := mx["key"].(K)
-			if kk == K(nil) {
-				continue
+// Deduplicate removes duplicate values within each group using the equal function. The first occurrence is kept
+func (ma MergeArray[K, V]) Deduplicate(equal func(a, b V) bool) MergeArray[K, V] {
+	result := make(MergeArray[K, V], 0, len(ma))
+	for _, m := range ma {
+		values := []V{}
+		for _, v := range m.Values {
+			duplicate := false
+			for _, existing := range values {
+				if equal(existing, v) {
+					duplicate = true
+					break
+				}
 			}
-			key := kk
-			if _, handled := handledKeys[key]; handled {
-				continue
+			if !duplicate {
+				values = append(values, v)
 			}
-			handledKeys[key] = true
-
-			values := values[K, V](sm, key)
+		}
+		result = append(result, Merge[K, V]{
+			Key:    m.Key,
+			Values: values,
+		})
+	}
+	return result
+}
 
-			result = append(result, Merge[K, V]{
-				Key:    pulumix.Val[K](key),
-				Values: values,
-			})
+// Filter keeps only the groups for which keep returns true
+func (ma MergeArray[K, V]) Filter(keep func(key K, values []V) bool) MergeArray[K, V] {
+	result := MergeArray[K, V]{}
+	for _, m := range ma {
+		if keep(awaitKey(m.Key), m.Values) {
+			result = append(result, m)
 		}
-		wg.Done()
-		return mi
+	}
+	return result
+}
+
+// SortByKey returns the groups sorted by their key using less
+func (ma MergeArray[K, V]) SortByKey(less func(a, b K) bool) MergeArray[K, V] {
+	keys := make([]K, len(ma))
+	for i, m := range ma {
+		keys[i] = awaitKey(m.Key)
+	}
+
+	indices := make([]int, len(ma))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return less(keys[indices[i]], keys[indices[j]])
 	})
-	wg.Wait()
+
+	result := make(MergeArray[K, V], 0, len(ma))
+	for _, i := range indices {
+		result = append(result, ma[i])
+	}
 	return result
 }
 
-func values[K allowedKeyType, V any](sm pulumix.Array[pulumix.Map[any]], key K) []V {
-	values := []V{}
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	pulumix.Apply[[]pulumix.Map[any], any](sm, func(mi []pulumix.Map[any]) any {
-		for _, mx := range mi {
-			if mx["key"] == nil || mx["values"] == nil {
-				continue
-			}
-			currentKey := mx["key"].(K)
-			currentValues := mx["values"]
-			if currentKey == key {
-				pulumix.All(currentValues).ApplyT(func(v any) any {
-					values = append(values, v.(V))
-					return values
-				})
+// GroupBy returns the values of all groups indexed by their key. Values of groups with the same key are appended
+func (ma MergeArray[K, V]) GroupBy() map[K][]V {
+	result := map[K][]V{}
+	keys := map[any]K{}
+	for _, m := range ma {
+		key := awaitKey(m.Key)
+		// use the first key with the same identity so pointer keys pointing to equal values are grouped
+		if id, ok := keyID(key); ok {
+			if existing, found := keys[id]; found {
+				key = existing
+			} else {
+				keys[id] = key
 			}
 		}
+		result[key] = append(result[key], m.Values...)
+	}
+	return result
+}
+
+// Len returns the number of groups
+func (ma MergeArray[K, V]) Len() int {
+	return len(ma)
+}
+
+// TotalValues returns the number of values across all groups
+func (ma MergeArray[K, V]) TotalValues() int {
+	total := 0
+	for _, m := range ma {
+		total += len(m.Values)
+	}
+	return total
+}
+
+func awaitKey[K allowedKeyType](key pulumix.Output[K]) K {
+	var result K
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	pulumix.Apply[K, K](key, func(k K) K {
+		result = k
 		wg.Done()
-		return mi
+		return k
 	})
 	wg.Wait()
-	return values
+	return result
 }
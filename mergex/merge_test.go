@@ -77,3 +77,182 @@ func TestMergeHostAliasesX(t *testing.T) {
 	assert.True(t, len(got) == 1)
 	assert.True(t, len(got[0].HostNames) == 2)
 }
+
+func TestMergeArrayDeduplicate(t *testing.T) {
+	alias1 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname1"},
+	}
+	alias2 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname1", "hostname2"},
+	}
+
+	got := MergeToMergeArray(alias1, alias2).Merge().Deduplicate(func(a, b string) bool {
+		return a == b
+	})
+
+	assert.True(t, len(got) == 1)
+	assert.ElementsMatch(t, []string{"hostname1", "hostname2"}, got[0].Values)
+}
+
+func TestMergeArrayFilter(t *testing.T) {
+	alias1 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname1"},
+	}
+	alias2 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname2"},
+	}
+	alias3 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.2")),
+		Values: []string{"hostname3"},
+	}
+
+	got := MergeToMergeArray(alias1, alias2, alias3).Merge().Filter(func(key *string, values []string) bool {
+		return *key == "192.168.0.2"
+	})
+
+	assert.True(t, len(got) == 1)
+	assert.Equal(t, []string{"hostname3"}, got[0].Values)
+
+	got = MergeToMergeArray(alias1, alias2, alias3).Merge().Filter(func(key *string, values []string) bool {
+		return len(values) > 1
+	})
+
+	assert.True(t, len(got) == 1)
+	assert.True(t, len(got[0].Values) == 2)
+}
+
+func TestMergeArraySortByKey(t *testing.T) {
+	aliases := []Merge[*string, string]{
+		{Key: pulumix.Val[*string](ptr.String("192.168.0.3")), Values: []string{"hostname3"}},
+		{Key: pulumix.Val[*string](ptr.String("192.168.0.1")), Values: []string{"hostname1"}},
+		{Key: pulumix.Val[*string](ptr.String("192.168.0.2")), Values: []string{"hostname2"}},
+	}
+
+	for i := 0; i < 5; i++ {
+		got := MergeToMergeArray(aliases...).Merge().SortByKey(func(a, b *string) bool {
+			return *a < *b
+		})
+
+		assert.True(t, len(got) == 3)
+		assert.Equal(t, []string{"hostname1"}, got[0].Values)
+		assert.Equal(t, []string{"hostname2"}, got[1].Values)
+		assert.Equal(t, []string{"hostname3"}, got[2].Values)
+	}
+}
+
+func TestMergeArrayMergeStringKey(t *testing.T) {
+	sma := MergeToMergeArray(
+		Merge[string, string]{Key: pulumix.Val("192.168.0.1"), Values: []string{"hostname1"}},
+		Merge[string, string]{Key: pulumix.Val("192.168.0.1"), Values: []string{"hostname2"}},
+		Merge[string, string]{Key: pulumix.Val("192.168.0.2"), Values: []string{"hostname3"}},
+	)
+	got := sma.Merge().SortByKey(func(a, b string) bool {
+		return a < b
+	})
+
+	assert.True(t, len(got) == 2)
+	assert.ElementsMatch(t, []string{"hostname1", "hostname2"}, got[0].Values)
+	assert.Equal(t, []string{"hostname3"}, got[1].Values)
+}
+
+func TestMergeArrayMergeIntKey(t *testing.T) {
+	sma := MergeToMergeArray(
+		Merge[int, string]{Key: pulumix.Val(80), Values: []string{"http"}},
+		Merge[int, string]{Key: pulumix.Val(443), Values: []string{"https"}},
+		Merge[int, string]{Key: pulumix.Val(80), Values: []string{"www"}},
+	)
+	got := sma.Merge().SortByKey(func(a, b int) bool {
+		return a < b
+	})
+
+	assert.True(t, len(got) == 2)
+	assert.ElementsMatch(t, []string{"http", "www"}, got[0].Values)
+	assert.Equal(t, []string{"https"}, got[1].Values)
+}
+
+func TestMergeArrayGroupBy(t *testing.T) {
+	alias1 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname1"},
+	}
+	alias2 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname2"},
+	}
+	alias3 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.2")),
+		Values: []string{"hostname3"},
+	}
+
+	got := MergeToMergeArray(alias1, alias2, alias3).Merge().GroupBy()
+
+	assert.Len(t, got, 2)
+	byIP := map[string][]string{}
+	for key, values := range got {
+		byIP[*key] = values
+	}
+	assert.ElementsMatch(t, []string{"hostname1", "hostname2"}, byIP["192.168.0.1"])
+	assert.Equal(t, []string{"hostname3"}, byIP["192.168.0.2"])
+}
+
+func TestMergeArrayLenAndTotalValues(t *testing.T) {
+	alias1 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname1"},
+	}
+	alias2 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.1")),
+		Values: []string{"hostname2"},
+	}
+	alias3 := Merge[*string, string]{
+		Key:    pulumix.Val[*string](ptr.String("192.168.0.2")),
+		Values: []string{"hostname3"},
+	}
+
+	sma := MergeToMergeArray(alias1, alias2, alias3)
+	assert.Equal(t, 3, sma.Len())
+	assert.Equal(t, 3, sma.TotalValues())
+
+	got := sma.Merge()
+	assert.Equal(t, 2, got.Len())
+	assert.Equal(t, 3, got.TotalValues())
+
+	assert.Equal(t, 0, MergeArray[*string, string]{}.Len())
+	assert.Equal(t, 0, MergeArray[*string, string]{}.TotalValues())
+}
+
+func TestMergeArrayMergeZeroKeys(t *testing.T) {
+	ints := MergeToMergeArray(
+		Merge[int, string]{Key: pulumix.Val(0), Values: []string{"zero"}},
+		Merge[int, string]{Key: pulumix.Val(1), Values: []string{"one"}},
+		Merge[int, string]{Key: pulumix.Val(0), Values: []string{"none"}},
+	).Merge().SortByKey(func(a, b int) bool {
+		return a < b
+	})
+
+	assert.Equal(t, 2, ints.Len())
+	assert.ElementsMatch(t, []string{"zero", "none"}, ints[0].Values)
+	assert.Equal(t, []string{"one"}, ints[1].Values)
+
+	bools := MergeToMergeArray(
+		Merge[bool, string]{Key: pulumix.Val(false), Values: []string{"disabled"}},
+		Merge[bool, string]{Key: pulumix.Val(true), Values: []string{"enabled"}},
+	).Merge().GroupBy()
+
+	assert.Len(t, bools, 2)
+	assert.Equal(t, []string{"disabled"}, bools[false])
+	assert.Equal(t, []string{"enabled"}, bools[true])
+
+	// nil pointer keys are still skipped
+	pointers := MergeToMergeArray(
+		Merge[*string, string]{Key: pulumix.Val[*string](nil), Values: []string{"unset"}},
+		Merge[*string, string]{Key: pulumix.Val[*string](ptr.String("")), Values: []string{"empty"}},
+	).Merge()
+
+	assert.Equal(t, 1, pointers.Len())
+	assert.Equal(t, []string{"empty"}, pointers[0].Values)
+}
@@ -82,3 +82,16 @@ func valuesS(sm pulumi.MapArray, key string) []string {
 	wg.Wait()
 	return values
 }
+
+// GroupByString returns the values of all groups indexed by their key. Groups without a key are skipped
+func (sma StringMergeArray) GroupByString() map[string][]string {
+	result := map[string][]string{}
+	for _, m := range sma {
+		key := awaitKey(m.Key)
+		if key == nil {
+			continue
+		}
+		result[*key] = append(result[*key], m.Values...)
+	}
+	return result
+}
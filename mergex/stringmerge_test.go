@@ -76,3 +76,24 @@ func TestMergeHostAliases(t *testing.T) {
 	assert.True(t, len(got) == 1)
 	assert.True(t, len(got[0].HostNames) == 2)
 }
+
+func TestStringMergeArrayGroupByString(t *testing.T) {
+	alias1 := StringMerge{
+		Key:    pulumix.MustConvertTyped[*string](pulumi.String("192.168.0.1").ToStringPtrOutput()),
+		Values: []string{"hostname1"},
+	}
+	alias2 := StringMerge{
+		Key:    pulumix.MustConvertTyped[*string](pulumi.String("192.168.0.1").ToStringPtrOutput()),
+		Values: []string{"hostname2"},
+	}
+	alias3 := StringMerge{
+		Key:    pulumix.MustConvertTyped[*string](pulumi.String("192.168.0.2").ToStringPtrOutput()),
+		Values: []string{"hostname3"},
+	}
+
+	got := StringMergeToStringMergeArray(alias1, alias2, alias3).Merge().GroupByString()
+
+	assert.Len(t, got, 2)
+	assert.ElementsMatch(t, []string{"hostname1", "hostname2"}, got["192.168.0.1"])
+	assert.Equal(t, []string{"hostname3"}, got["192.168.0.2"])
+}
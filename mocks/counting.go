@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/require"
+)
+
+// CountingMocks forwards all calls to Delegate and counts the created resources by type token
+type CountingMocks struct {
+	Delegate pulumi.MockResourceMonitor
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func NewCountingMocks(delegate pulumi.MockResourceMonitor) *CountingMocks {
+	return &CountingMocks{
+		Delegate: delegate,
+		counts:   map[string]int{},
+	}
+}
+
+func (m *CountingMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, state, err := m.Delegate.NewResource(args)
+	if err != nil {
+		return id, state, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// counts is nil when CountingMocks is used without NewCountingMocks
+	if m.counts == nil {
+		m.counts = map[string]int{}
+	}
+	m.counts[args.TypeToken]++
+	return id, state, nil
+}
+
+func (m *CountingMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return m.Delegate.Call(args)
+}
+
+// Count returns how many resources of typeToken were created
+func (m *CountingMocks) Count(typeToken string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[typeToken]
+}
+
+// AssertCreated fails the test unless exactly count resources of typeToken were created
+func (m *CountingMocks) AssertCreated(t *testing.T, typeToken string, count int) {
+	t.Helper()
+	require.Equalf(t, count, m.Count(typeToken), "unexpected number of %s resources", typeToken)
+}
+
+// AssertNotCreated fails the test if any resource of typeToken was created
+func (m *CountingMocks) AssertNotCreated(t *testing.T, typeToken string) {
+	t.Helper()
+	m.AssertCreated(t, typeToken, 0)
+}
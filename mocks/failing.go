@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// FailingMocks forwards all calls to Delegate but fails NewResource for the resource types listed in FailOn
+type FailingMocks struct {
+	Delegate pulumi.MockResourceMonitor
+	FailOn   []string
+}
+
+func (m FailingMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	for _, typeToken := range m.FailOn {
+		if typeToken == args.TypeToken {
+			return "", nil, fmt.Errorf("injected failure for %s of type %s", args.Name, args.TypeToken)
+		}
+	}
+	return m.Delegate.NewResource(args)
+}
+
+func (m FailingMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return m.Delegate.Call(args)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// MockCall is a single NewResource or Call invocation. For resources Token is the type token
+type MockCall struct {
+	Token   string
+	Args    resource.PropertyMap
+	Inputs  resource.PropertyMap
+	Outputs resource.PropertyMap
+	Err     error
+}
+
+// InstrumentedMocks forwards all calls to Delegate and records them
+type InstrumentedMocks struct {
+	Delegate pulumi.MockResourceMonitor
+
+	mu      sync.Mutex
+	history []MockCall
+}
+
+func (m *InstrumentedMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, state, err := m.Delegate.NewResource(args)
+	m.record(MockCall{
+		Token:   args.TypeToken,
+		Inputs:  args.Inputs,
+		Outputs: state,
+		Err:     err,
+	})
+	return id, state, err
+}
+
+func (m *InstrumentedMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	result, err := m.Delegate.Call(args)
+	m.record(MockCall{
+		Token:   args.Token,
+		Args:    args.Args,
+		Outputs: result,
+		Err:     err,
+	})
+	return result, err
+}
+
+func (m *InstrumentedMocks) record(call MockCall) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history = append(m.history, call)
+}
+
+// History returns all recorded invocations in the order they happened
+func (m *InstrumentedMocks) History() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall{}, m.history...)
+}
+
+// HistoryByToken returns the recorded invocations of token
+func (m *InstrumentedMocks) HistoryByToken(token string) []MockCall {
+	result := []MockCall{}
+	for _, call := range m.History() {
+		if call.Token == token {
+			result = append(result, call)
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"encoding/json"
+	"sync"
 
 	"github.com/mheers/pulumi-helper/mocks/provider"
 	pkgerrors "github.com/pkg/errors"
@@ -17,13 +18,6 @@ func (Mocks) NewResource(args pulumi.MockResourceArgs) (string, resource.Propert
 
 func (Mocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
 	if args.Token == "kubernetes:helm:template" {
-		kp, err := provider.MakeKubeProvider(nil, "test", "v1.28", []byte{})
-		if err != nil {
-			return nil, err
-		}
-
-		k8sProvider := kp.(*provider.KubeProvider)
-
 		var jsonOpts string
 		if jsonOptsArgs := args.Args["jsonOpts"]; jsonOptsArgs.HasValue() && jsonOptsArgs.IsString() {
 			jsonOpts = jsonOptsArgs.StringValue()
@@ -32,29 +26,103 @@ func (Mocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
 		}
 
 		var opts provider.HelmChartOpts
-		err = json.Unmarshal([]byte(jsonOpts), &opts)
+		err := json.Unmarshal([]byte(jsonOpts), &opts)
 		if err != nil {
 			return nil, pkgerrors.Wrap(err, "failed to unmarshal 'jsonOpts'")
 		}
 
-		text, err := k8sProvider.HelmTemplate(opts)
-		if err != nil {
-			// TODO: in unit tests this error is not seen / caught
-			return nil, pkgerrors.Wrap(err, "failed to generate YAML for specified Helm chart")
-		}
-
-		// Decode the generated YAML here to avoid an extra invoke in the client.
-		result, err := k8sProvider.DecodeYaml(text, opts.Namespace)
+		// TODO: in unit tests an error of the helm template is not seen / caught
+		result, err := HelmTemplateResult(opts)
 		if err != nil {
-			return nil, pkgerrors.Wrap(err, "failed to decode YAML for specified Helm chart")
+			return nil, err
 		}
 
 		return resource.NewPropertyMapFromMap(map[string]interface{}{"result": result}), nil
 	}
 
+	if args.Token == "kubernetes:yaml:decode" {
+		return invokeDecodeYaml(args)
+	}
+
+	if args.Token == "kubernetes:kustomize:directory" {
+		return invokeKustomize(args)
+	}
+
 	return args.Args, nil
 }
 
+// DefaultKubeVersion is the kubernetes version NewKubeProvider uses if none is given
+const DefaultKubeVersion = "v1.28"
+
+// NewKubeProvider creates a kubernetes provider without a cluster connection, which can render helm charts and
+// decode YAML in tests
+func NewKubeProvider(version string) (*provider.KubeProvider, error) {
+	if version == "" {
+		version = DefaultKubeVersion
+	}
+
+	kp, err := provider.MakeKubeProvider(nil, "test", version, []byte{})
+	if err != nil {
+		return nil, err
+	}
+	return kp.(*provider.KubeProvider), nil
+}
+
+var (
+	sharedKubeProviderOnce sync.Once
+	sharedKubeProvider     *provider.KubeProvider
+	sharedKubeProviderErr  error
+)
+
+// HelmTemplateResult renders the helm chart and decodes the manifest into its objects, using a kubernetes provider
+// that is shared by all calls
+func HelmTemplateResult(opts provider.HelmChartOpts) ([]interface{}, error) {
+	sharedKubeProviderOnce.Do(func() {
+		sharedKubeProvider, sharedKubeProviderErr = NewKubeProvider(DefaultKubeVersion)
+	})
+	if sharedKubeProviderErr != nil {
+		return nil, sharedKubeProviderErr
+	}
+
+	text, err := sharedKubeProvider.HelmTemplate(opts)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to generate YAML for specified Helm chart")
+	}
+
+	// Decode the generated YAML here to avoid an extra invoke in the client.
+	result, err := sharedKubeProvider.DecodeYaml(text, opts.Namespace)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to decode YAML for specified Helm chart")
+	}
+	return result, nil
+}
+
+func invokeDecodeYaml(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	k8sProvider, err := NewKubeProvider(DefaultKubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+	if textArg := args.Args["text"]; textArg.HasValue() && textArg.IsString() {
+		text = textArg.StringValue()
+	} else {
+		return nil, pkgerrors.New("missing required field 'text' of type string")
+	}
+
+	var defaultNamespace string
+	if nsArg := args.Args["defaultNamespace"]; nsArg.HasValue() && nsArg.IsString() {
+		defaultNamespace = nsArg.StringValue()
+	}
+
+	result, err := k8sProvider.DecodeYaml(text, defaultNamespace)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to decode specified YAML")
+	}
+
+	return resource.NewPropertyMapFromMap(map[string]interface{}{"result": result}), nil
+}
+
 func WithMocks(project, stack string, mocks pulumi.MockResourceMonitor) pulumi.RunOption {
 	return func(info *pulumi.RunInfo) {
 		info.Project, info.Stack, info.Mocks = project, stack, mocks
@@ -66,3 +134,29 @@ func WithMocksAndConfig(project, stack string, config map[string]string, mocks p
 		info.Project, info.Stack, info.Mocks, info.Config = project, stack, mocks, config
 	}
 }
+
+func invokeKustomize(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	k8sProvider, err := NewKubeProvider(DefaultKubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var directory string
+	if directoryArg := args.Args["directory"]; directoryArg.HasValue() && directoryArg.IsString() {
+		directory = directoryArg.StringValue()
+	} else {
+		return nil, pkgerrors.New("missing required field 'directory' of type string")
+	}
+
+	text, err := k8sProvider.Kustomize(directory)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to run kustomize on specified directory")
+	}
+
+	result, err := k8sProvider.DecodeYaml(text, "")
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to decode YAML for specified kustomize directory")
+	}
+
+	return resource.NewPropertyMapFromMap(map[string]interface{}{"result": result}), nil
+}
@@ -0,0 +1,179 @@
+package mocks
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mheers/pulumi-helper/mocks/provider"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	helmv3 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/require"
+)
+
+func program(ctx *pulumi.Context) error {
+	_, err := corev1.NewConfigMap(ctx, "cm", &corev1.ConfigMapArgs{
+		Data: pulumi.StringMap{"key": pulumi.String("value")},
+	})
+	return err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recording.json")
+
+	err := pulumi.RunErr(program, pulumi.WithMocks("demo-project", "demo-stack", NewRecordingMocks(Mocks(0), path)))
+	require.NoError(t, err)
+
+	replay, err := ReplayMocks(path)
+	require.NoError(t, err)
+
+	err = pulumi.RunErr(program, pulumi.WithMocks("demo-project", "demo-stack", replay))
+	require.NoError(t, err)
+
+	err = pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := corev1.NewSecret(ctx, "unknown", &corev1.SecretArgs{})
+		return err
+	}, pulumi.WithMocks("demo-project", "demo-stack", replay))
+	require.Error(t, err)
+}
+
+func TestFailingMocks(t *testing.T) {
+	err := pulumi.RunErr(program, pulumi.WithMocks("demo-project", "demo-stack", FailingMocks{
+		Delegate: Mocks(0),
+		FailOn:   []string{"kubernetes:core/v1:ConfigMap"},
+	}))
+	require.Error(t, err)
+
+	err = pulumi.RunErr(program, pulumi.WithMocks("demo-project", "demo-stack", FailingMocks{
+		Delegate: Mocks(0),
+		FailOn:   []string{"kubernetes:core/v1:Secret"},
+	}))
+	require.NoError(t, err)
+}
+
+func TestCountingMocksZeroValue(t *testing.T) {
+	counter := &CountingMocks{Delegate: Mocks(0)}
+	require.Equal(t, 0, counter.Count("kubernetes:core/v1:ConfigMap"))
+
+	err := pulumi.RunErr(program, pulumi.WithMocks("demo-project", "demo-stack", counter))
+	require.NoError(t, err)
+
+	counter.AssertCreated(t, "kubernetes:core/v1:ConfigMap", 1)
+	counter.AssertNotCreated(t, "kubernetes:core/v1:Secret")
+}
+
+func TestCallDecodeYaml(t *testing.T) {
+	result, err := Mocks(0).Call(pulumi.MockCallArgs{
+		Token: "kubernetes:yaml:decode",
+		Args: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"text": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+`,
+			"defaultNamespace": "demo",
+		}),
+	})
+	require.NoError(t, err)
+
+	objects := result["result"].ArrayValue()
+	require.Len(t, objects, 2)
+	require.Equal(t, "ConfigMap", objects[0].ObjectValue()["kind"].StringValue())
+}
+
+func TestWithMocksFromFile(t *testing.T) {
+	mocksFromFile, err := WithMocksFromFile("demo-project", "demo-stack", "testdata/fixture.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm, err := corev1.NewConfigMap(ctx, "cm", &corev1.ConfigMapArgs{
+			Data: pulumi.StringMap{"key": pulumi.String("value")},
+		})
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		cm.Data.ApplyT(func(data map[string]string) error {
+			require.Equal(t, "from-fixture", data["key"])
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
+		return nil
+	}, mocksFromFile)
+	require.NoError(t, err)
+
+	_, err = WithMocksFromFile("demo-project", "demo-stack", "testdata/missing.json")
+	require.Error(t, err)
+
+	malformed := filepath.Join(t.TempDir(), "malformed.json")
+	require.NoError(t, os.WriteFile(malformed, []byte("{"), 0644))
+	_, err = WithMocksFromFile("demo-project", "demo-stack", malformed)
+	require.Error(t, err)
+}
+
+func TestInstrumentedMocksHelmTemplate(t *testing.T) {
+	instrumented := &InstrumentedMocks{Delegate: Mocks(0)}
+
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := helmv3.NewChart(ctx, "demo", helmv3.ChartArgs{
+			Path:      pulumi.String("../helmx/testdata/demo"),
+			Namespace: pulumi.String("demo"),
+		})
+		return err
+	}, pulumi.WithMocks("demo-project", "demo-stack", instrumented))
+	require.NoError(t, err)
+
+	calls := instrumented.HistoryByToken("kubernetes:helm:template")
+	require.Len(t, calls, 1)
+	require.NoError(t, calls[0].Err)
+	require.True(t, calls[0].Args["jsonOpts"].IsString())
+
+	require.Len(t, instrumented.HistoryByToken("kubernetes:core/v1:Secret"), 1)
+	require.Greater(t, len(instrumented.History()), 1)
+}
+
+func TestCallKustomizeDirectory(t *testing.T) {
+	result, err := Mocks(0).Call(pulumi.MockCallArgs{
+		Token: "kubernetes:kustomize:directory",
+		Args: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"directory": "testdata/kustomize",
+		}),
+	})
+	require.NoError(t, err)
+
+	objects := result["result"].ArrayValue()
+	require.Len(t, objects, 1)
+	require.Equal(t, "ConfigMap", objects[0].ObjectValue()["kind"].StringValue())
+}
+
+func TestNewKubeProvider(t *testing.T) {
+	kp, err := NewKubeProvider("")
+	require.NoError(t, err)
+
+	result, err := kp.DecodeYaml("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n", "demo")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+}
+
+func TestHelmTemplateResult(t *testing.T) {
+	result, err := HelmTemplateResult(provider.HelmChartOpts{Path: "../helmx/testdata/demo", Namespace: "demo", ReleaseName: "demo"})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "Secret", result[0].(map[string]interface{})["kind"])
+}
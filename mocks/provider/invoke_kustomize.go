@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// kustomizeDirectory builds the kustomization in directory and returns the rendered YAML
+func kustomizeDirectory(directory string) (string, error) {
+	fSys := filesys.MakeFsOnDisk()
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resMap, err := k.Run(fSys, directory)
+	if err != nil {
+		return "", err
+	}
+
+	yamlBytes, err := resMap.AsYaml()
+	if err != nil {
+		return "", err
+	}
+
+	return string(yamlBytes), nil
+}
@@ -187,6 +187,10 @@ func (k *KubeProvider) HelmTemplate(opts HelmChartOpts) (string, error) {
 	return helmTemplate(opts, k.clientSet, k.defaultKubeVersion())
 }
 
+func (k *KubeProvider) Kustomize(directory string) (string, error) {
+	return kustomizeDirectory(directory)
+}
+
 func (k *KubeProvider) DecodeYaml(text string, defaultNamespace string) ([]interface{}, error) {
 	return decodeYaml(text, defaultNamespace, k.clientSet)
 }
@@ -0,0 +1,172 @@
+package mocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Recording holds all NewResource and Call invocations captured by RecordingMocks
+type Recording struct {
+	Resources []RecordedResource `json:"resources"`
+	Calls     []RecordedCall     `json:"calls"`
+}
+
+type RecordedResource struct {
+	TypeToken string                 `json:"typeToken"`
+	Name      string                 `json:"name"`
+	Inputs    map[string]interface{} `json:"inputs"`
+	ID        string                 `json:"id"`
+	State     map[string]interface{} `json:"state"`
+}
+
+type RecordedCall struct {
+	Token  string                 `json:"token"`
+	Args   map[string]interface{} `json:"args"`
+	Result map[string]interface{} `json:"result"`
+}
+
+// RecordingMocks forwards all calls to Delegate and writes them to Path after every call
+type RecordingMocks struct {
+	Delegate pulumi.MockResourceMonitor
+	Path     string
+
+	mu        sync.Mutex
+	recording Recording
+}
+
+func NewRecordingMocks(delegate pulumi.MockResourceMonitor, path string) *RecordingMocks {
+	return &RecordingMocks{
+		Delegate: delegate,
+		Path:     path,
+	}
+}
+
+func (m *RecordingMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, state, err := m.Delegate.NewResource(args)
+	if err != nil {
+		return id, state, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recording.Resources = append(m.recording.Resources, RecordedResource{
+		TypeToken: args.TypeToken,
+		Name:      args.Name,
+		Inputs:    args.Inputs.Mappable(),
+		ID:        id,
+		State:     state.Mappable(),
+	})
+	return id, state, m.save()
+}
+
+func (m *RecordingMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	result, err := m.Delegate.Call(args)
+	if err != nil {
+		return result, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recording.Calls = append(m.recording.Calls, RecordedCall{
+		Token:  args.Token,
+		Args:   args.Args.Mappable(),
+		Result: result.Mappable(),
+	})
+	return result, m.save()
+}
+
+func (m *RecordingMocks) save() error {
+	b, err := json.MarshalIndent(m.recording, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.Path, b, 0644)
+}
+
+type replayMocks struct {
+	recording Recording
+	// fixtures maps resource type tokens and call tokens to the properties they respond with
+	fixtures map[string]map[string]interface{}
+}
+
+// ReplayMocks returns a mock monitor that answers with the responses of a file written by RecordingMocks
+func ReplayMocks(path string) (pulumi.MockResourceMonitor, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &replayMocks{}
+	err = json.Unmarshal(b, &m.recording)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *replayMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	for _, r := range m.recording.Resources {
+		if r.TypeToken == args.TypeToken && r.Name == args.Name {
+			return r.ID, resource.NewPropertyMapFromMap(r.State), nil
+		}
+	}
+	if fixture, ok := m.fixtures[args.TypeToken]; ok {
+		state := args.Inputs.Mappable()
+		for key, value := range fixture {
+			state[key] = value
+		}
+		return args.Name + "_id", resource.NewPropertyMapFromMap(state), nil
+	}
+	return "", nil, fmt.Errorf("no recorded resource %s of type %s", args.Name, args.TypeToken)
+}
+
+func (m *replayMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	callArgs, err := normalize(args.Args.Mappable())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range m.recording.Calls {
+		if c.Token == args.Token && reflect.DeepEqual(c.Args, callArgs) {
+			return resource.NewPropertyMapFromMap(c.Result), nil
+		}
+	}
+	if fixture, ok := m.fixtures[args.Token]; ok {
+		return resource.NewPropertyMapFromMap(fixture), nil
+	}
+	return nil, fmt.Errorf("no recorded call %s with matching args", args.Token)
+}
+
+// WithMocksFromFile runs the program with mocks that answer from a JSON fixture file which maps resource type tokens
+// and call tokens to the properties they respond with
+func WithMocksFromFile(project, stack, path string) (pulumi.RunOption, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &replayMocks{}
+	err = json.Unmarshal(b, &m.fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse fixture file %s: %w", path, err)
+	}
+
+	return WithMocks(project, stack, m), nil
+}
+
+// normalize converts v to the types json.Unmarshal produces so it can be compared to recorded values
+func normalize(v map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(b, &result)
+	return result, err
+}
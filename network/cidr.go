@@ -0,0 +1,48 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// CIDRContains reports whether ip is within cidr
+func CIDRContains(cidr, ip string) (bool, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false, err
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false, fmt.Errorf("invalid ip address: %s", ip)
+	}
+	return ipNet.Contains(parsed), nil
+}
+
+// NthAddress returns the address at offset n from the network address of cidr, so n=1 is the first host address
+func NthAddress(cidr string, n int) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	if n < 0 {
+		return "", fmt.Errorf("n must not be negative: %d", n)
+	}
+
+	ip := ipNet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	offset := big.NewInt(int64(n))
+	if offset.Cmp(size) >= 0 {
+		return "", fmt.Errorf("address %d is out of range for %s", n, cidr)
+	}
+
+	value := new(big.Int).Add(new(big.Int).SetBytes(ip), offset)
+	result := make(net.IP, len(ip))
+	value.FillBytes(result)
+	return result.String(), nil
+}
@@ -0,0 +1,58 @@
+package network
+
+import "testing"
+
+func TestCIDRContains(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		ip       string
+		expected bool
+	}{
+		{"10.0.0.0/24", "10.0.0.42", true},
+		{"10.0.0.0/24", "10.0.1.1", false},
+		{"fd00::/64", "fd00::1", true},
+		{"fd00::/64", "fd01::1", false},
+	}
+
+	for _, tt := range tests {
+		got, err := CIDRContains(tt.cidr, tt.ip)
+		if err != nil {
+			t.Error(err)
+		}
+		if got != tt.expected {
+			t.Errorf("CIDRContains(%s, %s) = %v, expected %v", tt.cidr, tt.ip, got, tt.expected)
+		}
+	}
+
+	if _, err := CIDRContains("10.0.0.0/24", "invalid"); err == nil {
+		t.Error("expected an error for an invalid ip")
+	}
+}
+
+func TestNthAddress(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		n        int
+		expected string
+	}{
+		{"10.0.0.0/24", 0, "10.0.0.0"},
+		{"10.0.0.0/24", 1, "10.0.0.1"},
+		{"10.0.0.0/16", 300, "10.0.1.44"},
+		{"fd00::/64", 1, "fd00::1"},
+		{"fd00::/64", 65536, "fd00::1:0"},
+	}
+
+	for _, tt := range tests {
+		got, err := NthAddress(tt.cidr, tt.n)
+		if err != nil {
+			t.Error(err)
+		}
+		if got != tt.expected {
+			t.Errorf("NthAddress(%s, %d) = %s, expected %s", tt.cidr, tt.n, got, tt.expected)
+		}
+	}
+
+	if _, err := NthAddress("10.0.0.0/30", 4); err == nil {
+		t.Error("expected an error for an out of range address")
+	}
+}
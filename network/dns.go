@@ -0,0 +1,25 @@
+package network
+
+import (
+	"context"
+	"net"
+)
+
+// DNSLookup returns all A and AAAA records of the hostname
+func DNSLookup(hostname string) ([]net.IP, error) {
+	addrs, err := net.DefaultResolver.LookupIPAddr(context.Background(), hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+	return ips, nil
+}
+
+// ReverseDNS returns the host names the ip resolves to
+func ReverseDNS(ip string) ([]string, error) {
+	return net.DefaultResolver.LookupAddr(context.Background(), ip)
+}
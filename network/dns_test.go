@@ -0,0 +1,28 @@
+package network
+
+import (
+	"testing"
+)
+
+func TestDNSLookup(t *testing.T) {
+	ips, err := DNSLookup("localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) == 0 {
+		t.Fatal("expected localhost to resolve")
+	}
+	for _, ip := range ips {
+		if !ip.IsLoopback() {
+			t.Errorf("expected %s to be a loopback address", ip)
+		}
+	}
+}
+
+func TestReverseDNS(t *testing.T) {
+	names, err := ReverseDNS("127.0.0.1")
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(names)
+}
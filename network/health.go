@@ -0,0 +1,45 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HTTPHealthCheck requests the url every interval until it responds with a 2xx status or the context is done.
+// A nil client uses http.DefaultClient
+func HTTPHealthCheck(ctx context.Context, client *http.Client, url string, interval time.Duration) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if isHealthy(ctx, client, url) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func isHealthy(ctx context.Context, client *http.Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
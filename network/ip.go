@@ -1,15 +1,51 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // PublicIP returns the public ip of the caller
 func PublicIP() (string, error) {
 	// my public ip
-	resp, err := http.Get("https://ipv4.icanhazip.com")
+	return fetchIP("https://ipv4.icanhazip.com")
+}
+
+// PublicIPv6 returns the public IPv6 address of the caller
+func PublicIPv6() (string, error) {
+	return fetchIP("https://ipv6.icanhazip.com")
+}
+
+// PublicIPs fetches the public IPv4 and IPv6 addresses concurrently. An address is empty if the protocol is not
+// available. An error is only returned if neither address could be fetched
+func PublicIPs() (v4, v6 string, err error) {
+	var v4Err, v6Err error
+	g := errgroup.Group{}
+	g.Go(func() error {
+		v4, v4Err = PublicIP()
+		return nil
+	})
+	g.Go(func() error {
+		v6, v6Err = PublicIPv6()
+		return nil
+	})
+	_ = g.Wait()
+
+	if v4Err != nil && v6Err != nil {
+		return "", "", errors.Join(v4Err, v6Err)
+	}
+	return v4, v6, nil
+}
+
+func fetchIP(url string) (string, error) {
+	resp, err := http.Get(url)
 	if err != nil {
 		return "", err
 	}
@@ -22,3 +58,69 @@ func PublicIP() (string, error) {
 	ip = strings.ReplaceAll(ip, "\n", "")
 	return ip, nil
 }
+
+// LocalIPs returns all local interface addresses except loopback and link-local ones
+func LocalIPs() ([]net.IP, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []net.IP{}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+			continue
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// LocalIPv4s returns the IPv4 addresses of LocalIPs
+func LocalIPv4s() ([]net.IP, error) {
+	ips, err := LocalIPs()
+	if err != nil {
+		return nil, err
+	}
+
+	ipv4s := []net.IP{}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			ipv4s = append(ipv4s, ip)
+		}
+	}
+	return ipv4s, nil
+}
+
+// IsReachable reports whether a TCP connection to host:port can be established within the timeout
+func IsReachable(host, port string, timeout time.Duration) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), timeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// WaitForPort polls IsReachable every interval until host:port accepts TCP connections or the context is done
+func WaitForPort(ctx context.Context, host, port string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if IsReachable(host, port, interval) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
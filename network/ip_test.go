@@ -1,6 +1,12 @@
 package network
 
-import "testing"
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
 
 func TestPublicIP(t *testing.T) {
 	ip, err := PublicIP()
@@ -9,3 +15,126 @@ func TestPublicIP(t *testing.T) {
 	}
 	t.Log(ip)
 }
+
+func TestPublicIPs(t *testing.T) {
+	v4, v6, err := PublicIPs()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(v4, v6)
+}
+
+func TestLocalIPs(t *testing.T) {
+	ips, err := LocalIPs()
+	if err != nil {
+		t.Error(err)
+	}
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			t.Errorf("unexpected address %s", ip)
+		}
+	}
+	t.Log(ips)
+}
+
+func TestLocalIPv4s(t *testing.T) {
+	ips, err := LocalIPv4s()
+	if err != nil {
+		t.Error(err)
+	}
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			t.Errorf("%s is not an IPv4 address", ip)
+		}
+	}
+	t.Log(ips)
+}
+
+func TestIsReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsReachable(host, port, time.Second) {
+		t.Errorf("expected %s:%s to be reachable", host, port)
+	}
+
+	listener.Close()
+	if IsReachable(host, port, time.Second) {
+		t.Errorf("expected %s:%s to be unreachable", host, port)
+	}
+}
+
+func TestWaitForPort(t *testing.T) {
+	// reserve a free port and release it again, so the server can be started on it later
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan net.Listener, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Error(err)
+			close(started)
+			return
+		}
+		started <- l
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = WaitForPort(ctx, host, port, 20*time.Millisecond)
+	if err != nil {
+		t.Error(err)
+	}
+	if l, ok := <-started; ok {
+		l.Close()
+	}
+}
+
+func TestWaitForPortTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = WaitForPort(ctx, host, port, 20*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
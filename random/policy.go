@@ -0,0 +1,73 @@
+package random
+
+import (
+	"fmt"
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+const defaultMaxAttempts = 100
+
+// PasswordPolicy describes which character classes a password must contain
+type PasswordPolicy struct {
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	// MinEntropyBits is the minimum entropy of the password for DefaultAlphabet; 0 disables the check
+	MinEntropyBits float64
+	// MaxAttempts limits how often a password is generated before giving up. Defaults to 100
+	MaxAttempts int
+}
+
+// Satisfied reports whether password fulfills the policy
+func (p PasswordPolicy) Satisfied(password string) bool {
+	hasUpper, hasLower, hasDigit, hasSpecial := false, false, false, false
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSpecial = true
+		}
+	}
+	return (!p.RequireUpper || hasUpper) &&
+		(!p.RequireLower || hasLower) &&
+		(!p.RequireDigit || hasDigit) &&
+		(!p.RequireSpecial || hasSpecial) &&
+		MeetsEntropy(password, DefaultAlphabet, p.MinEntropyBits)
+}
+
+// PasswordEntropy returns the entropy in bits of a password whose characters are chosen randomly from alphabet
+func PasswordEntropy(password string, alphabet []rune) float64 {
+	if len(alphabet) == 0 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(password)) * math.Log2(float64(len(alphabet)))
+}
+
+// MeetsEntropy reports whether the entropy of the password is at least minBits
+func MeetsEntropy(password string, alphabet []rune, minBits float64) bool {
+	return PasswordEntropy(password, alphabet) >= minBits
+}
+
+// PasswordMustSatisfy creates random passwords until one satisfies the policy
+func PasswordMustSatisfy(minLength, maxLength int, policy PasswordPolicy) (string, error) {
+	attempts := policy.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+
+	for i := 0; i < attempts; i++ {
+		password := Password(minLength, maxLength)
+		if policy.Satisfied(password) {
+			return password, nil
+		}
+	}
+	return "", fmt.Errorf("no password satisfying the policy was generated in %d attempts", attempts)
+}
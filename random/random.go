@@ -1,18 +1,62 @@
 package random
 
 import (
-	"math/rand"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
 	"strings"
+	"sync"
 )
 
+var (
+	seededMu sync.Mutex
+	seeded   *mathrand.Rand
+)
+
+// Seed makes Password, Number, Bool and Shuffle use a deterministic sequence, which is meant for tests only. The generator is
+// shared package state guarded by a mutex: concurrent callers are safe but the order in which they draw numbers, and
+// therefore their results, is no longer deterministic. Without Seed crypto/rand is used
+func Seed(seed int64) {
+	seededMu.Lock()
+	defer seededMu.Unlock()
+	seeded = mathrand.New(mathrand.NewSource(seed))
+}
+
+// intn returns a random number in [0, n). It is cryptographically secure unless Seed was called
+func intn(n int) int {
+	seededMu.Lock()
+	if seeded != nil {
+		defer seededMu.Unlock()
+		return seeded.Intn(n)
+	}
+	seededMu.Unlock()
+
+	r, err := cryptoIntn(n)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// cryptoIntn returns a cryptographically secure random number in [0, n)
+func cryptoIntn(n int) (int, error) {
+	r, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(r.Int64()), nil
+}
+
+// DefaultAlphabet contains the characters Password chooses from
+var DefaultAlphabet = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+	"abcdefghijklmnopqrstuvwxyz" +
+	"0123456789" +
+	"!%&()=?")
+
 // Password creates a random password
 func Password(minLength, maxLength int) string {
-	chars := []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
-		"abcdefghijklmnopqrstuvwxyz" +
-		"0123456789" +
-		"!%&()=?")
-
-	return PasswordFromChars(minLength, maxLength, chars)
+	return PasswordFromChars(minLength, maxLength, DefaultAlphabet)
 }
 
 // Password creates a random password
@@ -29,13 +73,13 @@ func PasswordFromChars(minLength, maxLength int, chars []rune) string {
 
 	var b strings.Builder
 	for i := 0; i < maxLength; i++ {
-		b.WriteRune(chars[rand.Intn(len(chars))])
+		b.WriteRune(chars[intn(len(chars))])
 	}
 	str := b.String() // E.g. "ExcbsVQs"
 
 	length := maxLength
 	if maxLength > minLength {
-		length = rand.Intn(maxLength-minLength) + minLength
+		length = intn(maxLength-minLength) + minLength
 	}
 	str = str[:length]
 
@@ -54,10 +98,10 @@ func Number(min, max int) int {
 		min = 1
 	}
 
-	r := rand.Intn(max)
+	r := intn(max)
 
 	if max > min {
-		r = rand.Intn(max-min) + min
+		r = intn(max-min) + min
 	}
 
 	return r
@@ -65,5 +109,30 @@ func Number(min, max int) int {
 
 // Bool creates a random boolean
 func Bool() bool {
-	return rand.Intn(2) == 1
+	return intn(2) == 1
+}
+
+// Shuffle returns a copy of items in random order (Fisher-Yates)
+func Shuffle(items []string) []string {
+	result := make([]string, len(items))
+	copy(result, items)
+
+	for i := len(result) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// UUID creates a random RFC 4122 version 4 UUID
+func UUID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
@@ -1,6 +1,8 @@
 package random
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,3 +12,89 @@ func TestPassword(t *testing.T) {
 	got := Password(251, 251)
 	assert.Equal(t, 251, len(got))
 }
+
+func TestPasswordIsNotRepeated(t *testing.T) {
+	first := Password(32, 32)
+	second := Password(32, 32)
+	assert.NotEqual(t, first, second)
+}
+
+func TestUUID(t *testing.T) {
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	got := UUID()
+	assert.Regexp(t, uuidV4, got)
+	assert.NotEqual(t, got, UUID())
+}
+
+func TestSeed(t *testing.T) {
+	defer func() { seeded = nil }()
+
+	Seed(42)
+	first := []interface{}{Password(16, 16), Number(1, 100), Bool()}
+	Seed(42)
+	second := []interface{}{Password(16, 16), Number(1, 100), Bool()}
+
+	assert.Equal(t, first, second)
+}
+
+func TestWordPassword(t *testing.T) {
+	// some EFF words contain a hyphen themselves, e.g. "t-shirt"
+	got, err := WordPassword(4, " ")
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(got, " "), 4)
+
+	_, err = WordPassword(0, "-")
+	assert.Error(t, err)
+}
+
+func TestParseWordlist(t *testing.T) {
+	assert.Equal(t, []string{"abacus", "abdomen"}, parseWordlist("11111\tabacus\n11112\tabdomen\n"))
+	assert.Equal(t, []string{"abacus", "abdomen"}, parseWordlist("abacus\nabdomen\n"))
+}
+
+func TestEmbeddedWordlist(t *testing.T) {
+	// the EFF large wordlist has one word for every roll of five dice
+	assert.Len(t, words, 7776)
+	assert.Equal(t, "abacus", words[0])
+	assert.Equal(t, "zoom", words[len(words)-1])
+}
+
+func TestPasswordMustSatisfy(t *testing.T) {
+	policy := PasswordPolicy{
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+
+	got, err := PasswordMustSatisfy(16, 16, policy)
+	assert.NoError(t, err)
+	assert.Len(t, got, 16)
+	assert.True(t, policy.Satisfied(got))
+
+	// a single character can never contain all classes
+	_, err = PasswordMustSatisfy(1, 1, PasswordPolicy{RequireUpper: true, RequireDigit: true, MaxAttempts: 10})
+	assert.Error(t, err)
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	assert.Equal(t, 8.0, PasswordEntropy("abcd", []rune("0123")))
+	assert.Equal(t, 0.0, PasswordEntropy("abcd", nil))
+	assert.True(t, MeetsEntropy("abcd", []rune("0123"), 8))
+	assert.False(t, MeetsEntropy("abc", []rune("0123"), 8))
+
+	// 16 characters of the 69 characters of the default alphabet have ~97.7 bits
+	_, err := PasswordMustSatisfy(16, 16, PasswordPolicy{MinEntropyBits: 90})
+	assert.NoError(t, err)
+	_, err = PasswordMustSatisfy(8, 8, PasswordPolicy{MinEntropyBits: 90, MaxAttempts: 10})
+	assert.Error(t, err)
+}
+
+func TestShuffle(t *testing.T) {
+	items := []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}
+
+	got := Shuffle(items)
+	assert.ElementsMatch(t, items, got)
+	assert.Equal(t, []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}, items)
+}
@@ -0,0 +1,44 @@
+package random
+
+import (
+	_ "embed"
+	"errors"
+	"strings"
+)
+
+// wordlist is the EFF large wordlist (https://www.eff.org/files/2016/07/18/eff_large_wordlist.txt) in its
+// "11111<tab>abacus" format. Plain lists with one word per line are supported as well
+//
+//go:embed wordlist.txt
+var wordlist string
+
+var words = parseWordlist(wordlist)
+
+func parseWordlist(list string) []string {
+	result := []string{}
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		result = append(result, fields[len(fields)-1])
+	}
+	return result
+}
+
+// WordPassword creates a passphrase like "correct-horse-battery-staple" from wordCount random words
+func WordPassword(wordCount int, separator string) (string, error) {
+	if wordCount < 1 {
+		return "", errors.New("wordCount must be at least 1")
+	}
+
+	selected := make([]string, wordCount)
+	for i := range selected {
+		n, err := cryptoIntn(len(words))
+		if err != nil {
+			return "", err
+		}
+		selected[i] = words[n]
+	}
+	return strings.Join(selected, separator), nil
+}
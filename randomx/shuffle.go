@@ -0,0 +1,21 @@
+package randomx
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// ShuffleAny returns a copy of items in a cryptographically secure random order (Fisher-Yates)
+func ShuffleAny[T any](items []T) []T {
+	result := make([]T, len(items))
+	copy(result, items)
+
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			panic(err)
+		}
+		result[i], result[j.Int64()] = result[j.Int64()], result[i]
+	}
+	return result
+}
@@ -0,0 +1,17 @@
+package randomx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffleAny(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	got := ShuffleAny(items)
+	assert.ElementsMatch(t, items, got)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, items)
+
+	assert.Empty(t, ShuffleAny([]string{}))
+}
@@ -0,0 +1,158 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ErrConfigKeyNotFound is returned when a config key does not exist in a stack
+var ErrConfigKeyNotFound = errors.New("config key not found")
+
+// GetConfig returns the raw value of a config key of the given stack
+func GetConfig(stackName, key string, opts ...Option) (string, error) {
+	y, err := ReadStackYaml(stackName, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := y.Config[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s in stack %s", ErrConfigKeyNotFound, key, stackName)
+	}
+
+	return value, nil
+}
+
+// SetConfig writes the value of a config key to the given stack
+func SetConfig(stackName, key, value string, opts ...Option) error {
+	y, err := ReadStackYaml(stackName, opts...)
+	if err != nil {
+		return err
+	}
+
+	if y.Config == nil {
+		y.Config = map[string]string{}
+	}
+	y.Config[key] = value
+
+	return WriteStackYaml(stackName, y, opts...)
+}
+
+// DeleteConfig removes a config key from the given stack
+func DeleteConfig(stackName, key string, opts ...Option) error {
+	y, err := ReadStackYaml(stackName, opts...)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := y.Config[key]; !ok {
+		return fmt.Errorf("%w: %s in stack %s", ErrConfigKeyNotFound, key, stackName)
+	}
+	delete(y.Config, key)
+
+	return WriteStackYaml(stackName, y, opts...)
+}
+
+// ApplyConfigTemplate renders every config value of the given stack as a text/template with data as context and
+// returns the result. The stack file is not modified
+func ApplyConfigTemplate(stackName string, data map[string]interface{}, opts ...Option) (*PulumiStackYaml, error) {
+	y, err := ReadStackYaml(stackName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PulumiStackYaml{
+		Encryptionsalt: y.Encryptionsalt,
+		Config:         make(map[string]string, len(y.Config)),
+	}
+	for key, value := range y.Config {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse template of %s: %w", key, err)
+		}
+
+		var b strings.Builder
+		err = tmpl.Execute(&b, data)
+		if err != nil {
+			return nil, fmt.Errorf("could not render template of %s: %w", key, err)
+		}
+		result.Config[key] = b.String()
+	}
+
+	return result, nil
+}
+
+// MergeConfigs returns the config of the base stack overwritten by the config of the overlay stack. The result uses
+// the encryption salt of the overlay; no stack file is written
+func MergeConfigs(base, overlay string, opts ...Option) (*PulumiStackYaml, error) {
+	b, err := ReadStackYaml(base, opts...)
+	if err != nil {
+		return nil, err
+	}
+	o, err := ReadStackYaml(overlay, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PulumiStackYaml{
+		Encryptionsalt: o.Encryptionsalt,
+		Config:         make(map[string]string, len(b.Config)+len(o.Config)),
+	}
+	for key, value := range b.Config {
+		result.Config[key] = value
+	}
+	for key, value := range o.Config {
+		result.Config[key] = value
+	}
+
+	return result, nil
+}
+
+const (
+	ConfigAdded   = "added"
+	ConfigRemoved = "removed"
+	ConfigChanged = "changed"
+)
+
+// ConfigChange describes the change of a single config key between two stacks
+type ConfigChange struct {
+	OldValue string
+	NewValue string
+	Change   string
+}
+
+// ConfigDiff maps config keys to their changes
+type ConfigDiff map[string]ConfigChange
+
+// DiffConfigs compares the config of two stacks. Keys with equal values are not part of the result
+func DiffConfigs(stackA, stackB string, opts ...Option) (ConfigDiff, error) {
+	a, err := ReadStackYaml(stackA, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ReadStackYaml(stackB, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := ConfigDiff{}
+	for key, oldValue := range a.Config {
+		newValue, ok := b.Config[key]
+		if !ok {
+			diff[key] = ConfigChange{OldValue: oldValue, Change: ConfigRemoved}
+			continue
+		}
+		if oldValue != newValue {
+			diff[key] = ConfigChange{OldValue: oldValue, NewValue: newValue, Change: ConfigChanged}
+		}
+	}
+	for key, newValue := range b.Config {
+		if _, ok := a.Config[key]; !ok {
+			diff[key] = ConfigChange{NewValue: newValue, Change: ConfigAdded}
+		}
+	}
+
+	return diff, nil
+}
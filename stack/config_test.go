@@ -0,0 +1,167 @@
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfig(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{
+		Config: map[string]string{
+			"app:name": "demo",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	value, err := GetConfig("dev", "app:name", opt)
+	require.NoError(t, err)
+	require.Equal(t, "demo", value)
+
+	_, err = GetConfig("dev", "app:missing", opt)
+	require.ErrorIs(t, err, ErrConfigKeyNotFound)
+
+	_, err = GetConfig("prod", "app:name", opt)
+	require.Error(t, err)
+}
+
+func TestSetConfig(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{}, opt)
+	require.NoError(t, err)
+
+	err = SetConfig("dev", "app:name", "demo", opt)
+	require.NoError(t, err)
+
+	value, err := GetConfig("dev", "app:name", opt)
+	require.NoError(t, err)
+	require.Equal(t, "demo", value)
+
+	err = SetConfig("dev", "app:name", "other", opt)
+	require.NoError(t, err)
+
+	value, err = GetConfig("dev", "app:name", opt)
+	require.NoError(t, err)
+	require.Equal(t, "other", value)
+
+	err = SetConfig("prod", "app:name", "demo", opt)
+	require.Error(t, err)
+}
+
+func TestDeleteConfig(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{
+		Config: map[string]string{
+			"app:name":  "demo",
+			"app:other": "value",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	err = DeleteConfig("dev", "app:name", opt)
+	require.NoError(t, err)
+
+	_, err = GetConfig("dev", "app:name", opt)
+	require.ErrorIs(t, err, ErrConfigKeyNotFound)
+
+	value, err := GetConfig("dev", "app:other", opt)
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	err = DeleteConfig("dev", "app:name", opt)
+	require.ErrorIs(t, err, ErrConfigKeyNotFound)
+}
+
+func TestDiffConfigs(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{
+		Config: map[string]string{
+			"app:name":    "demo",
+			"app:size":    "small",
+			"app:debug":   "true",
+			"app:replica": "1",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	err = WriteStackYaml("prod", &PulumiStackYaml{
+		Config: map[string]string{
+			"app:name":    "demo",
+			"app:size":    "large",
+			"app:replica": "1",
+			"app:domain":  "example.com",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	diff, err := DiffConfigs("dev", "prod", opt)
+	require.NoError(t, err)
+	require.Equal(t, ConfigDiff{
+		"app:size":   {OldValue: "small", NewValue: "large", Change: ConfigChanged},
+		"app:debug":  {OldValue: "true", Change: ConfigRemoved},
+		"app:domain": {NewValue: "example.com", Change: ConfigAdded},
+	}, diff)
+}
+
+func TestApplyConfigTemplate(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{
+		Config: map[string]string{
+			"app:cluster": "{{ .StackName }}-cluster",
+			"app:name":    "demo",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	y, err := ApplyConfigTemplate("dev", map[string]interface{}{"StackName": "dev"}, opt)
+	require.NoError(t, err)
+	require.Equal(t, "dev-cluster", y.Config["app:cluster"])
+	require.Equal(t, "demo", y.Config["app:name"])
+
+	original, err := ReadStackYaml("dev", opt)
+	require.NoError(t, err)
+	require.Equal(t, "{{ .StackName }}-cluster", original.Config["app:cluster"])
+
+	_, err = ApplyConfigTemplate("dev", map[string]interface{}{}, opt)
+	require.Error(t, err)
+}
+
+func TestMergeConfigs(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("base", &PulumiStackYaml{
+		Encryptionsalt: "base-salt",
+		Config: map[string]string{
+			"app:name": "demo",
+			"app:size": "small",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	err = WriteStackYaml("prod", &PulumiStackYaml{
+		Encryptionsalt: "prod-salt",
+		Config: map[string]string{
+			"app:size":   "large",
+			"app:domain": "example.com",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	y, err := MergeConfigs("base", "prod", opt)
+	require.NoError(t, err)
+	require.Equal(t, "prod-salt", y.Encryptionsalt)
+	require.Equal(t, map[string]string{
+		"app:name":   "demo",
+		"app:size":   "large",
+		"app:domain": "example.com",
+	}, y.Config)
+
+	_, err = MergeConfigs("base", "missing", opt)
+	require.Error(t, err)
+}
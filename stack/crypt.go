@@ -2,11 +2,16 @@ package stack
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
-	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
@@ -15,15 +20,48 @@ func encryptionSalt(ctx *pulumi.Context) (string, error) {
 	return encryptionSaltByStackName(stackName)
 }
 
-func encryptionSaltByStackName(stackName string) (string, error) {
-	y, err := ReadStackYaml(stackName)
+func encryptionSaltByStackName(stackName string, opts ...Option) (string, error) {
+	y, err := ReadStackYaml(stackName, opts...)
 	if err != nil {
 		return "", err
 	}
 	return y.Encryptionsalt, nil
 }
 
-var secretsManager secrets.Manager
+// SecretsProvider encrypts and decrypts stack config values
+type SecretsProvider interface {
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+var secretsProvider SecretsProvider
+
+// RegisterSecretsProvider sets the provider that is used by Encrypt and Decrypt
+func RegisterSecretsProvider(provider SecretsProvider) {
+	secretsProvider = provider
+}
+
+type passphraseProvider struct {
+	crypter config.Crypter
+}
+
+// NewPassphraseProvider returns a SecretsProvider using Pulumi's passphrase encryption. The passphrase is checked
+// against the salt on every call, as Pulumi's passphrase secrets managers are cached by salt only
+func NewPassphraseProvider(phrase, salt string) (SecretsProvider, error) {
+	crypter, err := crypterFromSalt(phrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	return &passphraseProvider{crypter: crypter}, nil
+}
+
+func (p *passphraseProvider) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	return p.crypter.EncryptValue(ctx, plaintext)
+}
+
+func (p *passphraseProvider) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	return p.crypter.DecryptValue(ctx, ciphertext)
+}
 
 func InitCrypterWithSaltAndPassphrase(salt, passphrase string) error {
 	if err := os.Setenv("PULUMI_CONFIG_PASSPHRASE", passphrase); err != nil {
@@ -40,8 +78,8 @@ func InitCrypter(ctx *pulumi.Context) error {
 	return initCrypter(salt)
 }
 
-func InitCrypterForProject(name string) error {
-	salt, err := encryptionSaltByStackName(name)
+func InitCrypterForProject(name string, opts ...Option) error {
+	salt, err := encryptionSaltByStackName(name, opts...)
 	if err != nil {
 		return err
 	}
@@ -50,7 +88,7 @@ func InitCrypterForProject(name string) error {
 
 func initCrypter(salt string) error {
 	// only initialize once
-	if secretsManager != nil {
+	if secretsProvider != nil {
 		return nil
 	}
 
@@ -58,43 +96,148 @@ func initCrypter(salt string) error {
 	if pp == "" {
 		return errors.New("PULUMI_CONFIG_PASSPHRASE is not set")
 	}
-	var err error
-	secretsManager, err = passphrase.GetPassphraseSecretsManager(pp, salt)
+	provider, err := NewPassphraseProvider(pp, salt)
 	if err != nil {
 		return err
 	}
+	RegisterSecretsProvider(provider)
 
 	return nil
 }
 
 func Encrypt(value string) (string, error) {
-	if secretsManager == nil {
-		return "", errors.New("secretsManager is not initialized")
+	if secretsProvider == nil {
+		return "", errors.New("secretsProvider is not initialized")
 	}
 
-	enc, err := secretsManager.Encrypter()
+	return secretsProvider.Encrypt(context.Background(), value)
+}
+
+func Decrypt(value string) (string, error) {
+	if secretsProvider == nil {
+		return "", errors.New("secretsProvider is not initialized")
+	}
+
+	return secretsProvider.Decrypt(context.Background(), value)
+}
+
+// RotateEncryption re-encrypts all secret config values of a stack with a new passphrase and a freshly generated salt
+func RotateEncryption(stackName, oldPassphrase, newPassphrase string, opts ...Option) error {
+	y, err := ReadStackYaml(stackName, opts...)
 	if err != nil {
-		return "", err
+		return err
 	}
-	encrypted, err := enc.EncryptValue(context.Background(), value)
+
+	oldProvider, err := NewPassphraseProvider(oldPassphrase, y.Encryptionsalt)
 	if err != nil {
-		return "", err
+		return errors.Join(err, errors.New("could not initialize crypter with old passphrase"))
+	}
+
+	salt, err := newSalt(newPassphrase)
+	if err != nil {
+		return err
+	}
+	newProvider, err := NewPassphraseProvider(newPassphrase, salt)
+	if err != nil {
+		return errors.Join(err, errors.New("could not initialize crypter with new passphrase"))
 	}
-	return encrypted, nil
+
+	ctx := context.Background()
+	rotated := make(map[string]string, len(y.Config))
+	for key, value := range y.Config {
+		if !IsEncrypted(value) {
+			rotated[key] = value
+			continue
+		}
+
+		decrypted, err := oldProvider.Decrypt(ctx, value)
+		if err != nil {
+			return fmt.Errorf("could not decrypt %s: %w", key, err)
+		}
+		encrypted, err := newProvider.Encrypt(ctx, decrypted)
+		if err != nil {
+			return fmt.Errorf("could not encrypt %s: %w", key, err)
+		}
+		rotated[key] = encrypted
+	}
+
+	return writeStackYamlAtomic(stackName, &PulumiStackYaml{
+		Encryptionsalt: salt,
+		Config:         rotated,
+	}, opts...)
 }
 
-func Decrypt(value string) (string, error) {
-	if secretsManager == nil {
-		return "", errors.New("secretsManager is not initialized")
+// GenerateSalt creates a new encryption salt for the passphrase set in PULUMI_CONFIG_PASSPHRASE
+func GenerateSalt() (string, error) {
+	pp := os.Getenv("PULUMI_CONFIG_PASSPHRASE")
+	if pp == "" {
+		return "", errors.New("PULUMI_CONFIG_PASSPHRASE is not set")
 	}
+	return newSalt(pp)
+}
 
-	dec, err := secretsManager.Decrypter()
-	if err != nil {
+// newSalt creates a salt in the format of the passphrase secrets manager: v1:<salt>:<encrypted "pulumi">
+func newSalt(phrase string) (string, error) {
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
 		return "", err
 	}
-	decrypted, err := dec.DecryptValue(context.Background(), value)
+
+	crypter := config.NewSymmetricCrypterFromPassphrase(phrase, salt)
+	msg, err := crypter.EncryptValue(context.Background(), "pulumi")
 	if err != nil {
 		return "", err
 	}
-	return decrypted, nil
+
+	return fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg), nil
+}
+
+// crypterFromSalt returns the crypter for a salt created by newSalt if the passphrase decrypts its check value
+func crypterFromSalt(phrase, salt string) (config.Crypter, error) {
+	parts := strings.SplitN(salt, ":", 3)
+	if len(parts) != 3 || parts[0] != "v1" {
+		return nil, errors.New("malformed encryption salt")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	crypter := config.NewSymmetricCrypterFromPassphrase(phrase, raw)
+	check, err := crypter.DecryptValue(context.Background(), parts[2])
+	if err != nil || check != "pulumi" {
+		return nil, passphrase.ErrIncorrectPassphrase
+	}
+	return crypter, nil
+}
+
+// IsEncrypted returns true if the value is a ciphertext of the passphrase secrets manager.
+// A ciphertext has the format v<version>:<base64 nonce>:<base64 ciphertext>
+func IsEncrypted(value string) bool {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return false
+	}
+
+	version, nonce, ciphertext := parts[0], parts[1], parts[2]
+	if !strings.HasPrefix(version, "v") {
+		return false
+	}
+	if _, err := strconv.ParseUint(strings.TrimPrefix(version, "v"), 10, 32); err != nil {
+		return false
+	}
+
+	n, err := base64.StdEncoding.DecodeString(nonce)
+	if err != nil || len(n) == 0 {
+		return false
+	}
+
+	// AES-256-GCM appends a 16 byte authentication tag to every ciphertext
+	c, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(c) < 16 {
+		return false
+	}
+
+	return true
 }
@@ -1,9 +1,12 @@
 package stack
 
 import (
+	"context"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,3 +55,207 @@ func TestEncryptDecrypt(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "test", decrypted)
 }
+
+func TestRotateEncryption(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{
+		Encryptionsalt: "v1:LAQ7P6sT/+w=:v1:WejwuMb5G4TZsR/r:xZvrv45hbT2QRrHCkQrepVv3xQfMjw==",
+		Config: map[string]string{
+			"app:password": "v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+			"app:name":     "demo",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	err = RotateEncryption("dev", "foo", "bar", opt)
+	require.NoError(t, err)
+
+	y, err := ReadStackYaml("dev", opt)
+	require.NoError(t, err)
+	require.NotEqual(t, "v1:LAQ7P6sT/+w=:v1:WejwuMb5G4TZsR/r:xZvrv45hbT2QRrHCkQrepVv3xQfMjw==", y.Encryptionsalt)
+	require.Equal(t, "demo", y.Config["app:name"])
+	require.NotEqual(t, "v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=", y.Config["app:password"])
+
+	manager, err := passphrase.GetPassphraseSecretsManager("bar", y.Encryptionsalt)
+	require.NoError(t, err)
+	dec, err := manager.Decrypter()
+	require.NoError(t, err)
+	decrypted, err := dec.DecryptValue(context.Background(), y.Config["app:password"])
+	require.NoError(t, err)
+	require.Equal(t, "test", decrypted)
+
+	_, err = NewPassphraseProvider("foo", y.Encryptionsalt)
+	require.Error(t, err)
+}
+
+func TestRotateEncryptionWrongPassphrase(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{
+		Encryptionsalt: "v1:LAQ7P6sT/+w=:v1:WejwuMb5G4TZsR/r:xZvrv45hbT2QRrHCkQrepVv3xQfMjw==",
+		Config: map[string]string{
+			"app:password": "v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+		},
+	}, opt)
+	require.NoError(t, err)
+
+	err = RotateEncryption("dev", "wrong", "bar", opt)
+	require.Error(t, err)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{
+			name:  "ciphertext",
+			value: "v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+			want:  true,
+		},
+		{
+			name:  "ciphertext of a longer value",
+			value: "v1:D8D7cmOhI3pMhAG5:UOW+JAdt1vX/GrJSQoiwWXMGEgacCCGCuzgbe2vvYw==",
+			want:  true,
+		},
+		{
+			name:  "future version",
+			value: "v2:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+			want:  true,
+		},
+		{
+			name:  "empty",
+			value: "",
+			want:  false,
+		},
+		{
+			name:  "plaintext",
+			value: "test",
+			want:  false,
+		},
+		{
+			name:  "plaintext with v1 prefix",
+			value: "v1:hello:world",
+			want:  false,
+		},
+		{
+			name:  "missing version",
+			value: "fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+			want:  false,
+		},
+		{
+			name:  "invalid version",
+			value: "vx:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+			want:  false,
+		},
+		{
+			name:  "empty nonce",
+			value: "v1::DrMqOwJhAMQPuc6GssWyi7ggM9Y=",
+			want:  false,
+		},
+		{
+			name:  "ciphertext too short",
+			value: "v1:fYYADOWNT7IqCV0V:dGVzdA==",
+			want:  false,
+		},
+		{
+			name:  "too many parts",
+			value: "v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=:foo",
+			want:  false,
+		},
+		{
+			name:  "url",
+			value: "https://example.com:8080",
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsEncrypted(tt.value))
+		})
+	}
+}
+
+func TestIsEncryptedEncryptedValue(t *testing.T) {
+	os.Setenv("PULUMI_CONFIG_PASSPHRASE", "foo")
+	salt := "v1:LAQ7P6sT/+w=:v1:WejwuMb5G4TZsR/r:xZvrv45hbT2QRrHCkQrepVv3xQfMjw=="
+	err := initCrypter(salt)
+	require.NoError(t, err)
+
+	encrypted, err := Encrypt("")
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+}
+
+type reverseProvider struct{}
+
+func (reverseProvider) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	r := []rune(plaintext)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r), nil
+}
+
+func (p reverseProvider) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	return p.Encrypt(ctx, ciphertext)
+}
+
+func TestRegisterSecretsProvider(t *testing.T) {
+	oldProvider := secretsProvider
+	t.Cleanup(func() { secretsProvider = oldProvider })
+
+	RegisterSecretsProvider(reverseProvider{})
+
+	encrypted, err := Encrypt("test")
+	require.NoError(t, err)
+	require.Equal(t, "tset", encrypted)
+
+	decrypted, err := Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "test", decrypted)
+}
+
+func TestNewPassphraseProvider(t *testing.T) {
+	salt := "v1:LAQ7P6sT/+w=:v1:WejwuMb5G4TZsR/r:xZvrv45hbT2QRrHCkQrepVv3xQfMjw=="
+	provider, err := NewPassphraseProvider("foo", salt)
+	require.NoError(t, err)
+
+	decrypted, err := provider.Decrypt(context.Background(), "v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y=")
+	require.NoError(t, err)
+	require.Equal(t, "test", decrypted)
+
+	_, err = NewPassphraseProvider("bar", salt)
+	require.Error(t, err)
+}
+
+func TestGenerateSalt(t *testing.T) {
+	os.Setenv("PULUMI_CONFIG_PASSPHRASE", "foo")
+	salt, err := GenerateSalt()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(salt, "v1:"))
+
+	other, err := GenerateSalt()
+	require.NoError(t, err)
+	require.NotEqual(t, salt, other)
+
+	provider, err := NewPassphraseProvider("foo", salt)
+	require.NoError(t, err)
+	encrypted, err := provider.Encrypt(context.Background(), "test")
+	require.NoError(t, err)
+	decrypted, err := provider.Decrypt(context.Background(), encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "test", decrypted)
+
+	_, err = NewPassphraseProvider("bar", salt)
+	require.Error(t, err)
+}
+
+func TestGenerateSaltWithoutPassphrase(t *testing.T) {
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "")
+	_, err := GenerateSalt()
+	require.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package stack
+
+import (
+	"fmt"
+	"path"
+)
+
+// Option configures the directory the stack functions operate on
+type Option func(*options)
+
+type options struct {
+	baseDir string
+}
+
+// WithBaseDir makes a stack function operate on dir instead of BaseDir
+func WithBaseDir(dir string) Option {
+	return func(o *options) {
+		o.baseDir = dir
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		baseDir: BaseDir,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// stackFile returns the path of the stack file of the given stack
+func (o *options) stackFile(name string) string {
+	return path.Join(o.baseDir, fmt.Sprintf("Pulumi.%s.yaml", name))
+}
+
+// projectFile returns the path of the Pulumi.yaml
+func (o *options) projectFile() string {
+	return path.Join(o.baseDir, "Pulumi.yaml")
+}
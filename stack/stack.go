@@ -5,14 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mheers/pulumi-helper/workspace"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v3"
 )
 
+// BaseDir is the directory of the Pulumi project used when no WithBaseDir option is given
 var BaseDir = "."
 
 type PulumiYaml struct {
@@ -33,8 +36,8 @@ type Stack struct {
 	Configuration *PulumiStackYaml
 }
 
-func StackName() (string, error) {
-	project, err := ProjectName()
+func StackName(opts ...Option) (string, error) {
+	project, err := ProjectName(opts...)
 	if err != nil {
 		return "", errors.Join(err, errors.New("could not get stack name"))
 	}
@@ -46,15 +49,17 @@ func StackName() (string, error) {
 
 	space, ok := spaces[project]
 	if !ok {
-		return "", errors.New("no workspace found")
+		return "", workspace.ErrNoWorkspace
 	}
 
 	return space.Stack, nil
 }
 
-func SetStack(newStack string) error {
+func SetStack(newStack string, opts ...Option) error {
+	o := newOptions(opts)
+
 	// check if stack exists
-	stacks, err := FindStacks(BaseDir)
+	stacks, err := FindStacks(o.baseDir)
 	if err != nil {
 		return err
 	}
@@ -71,7 +76,7 @@ func SetStack(newStack string) error {
 		return fmt.Errorf("stack %s not found", newStack)
 	}
 
-	project, err := ProjectName()
+	project, err := ProjectName(opts...)
 	if err != nil {
 		return err
 	}
@@ -89,15 +94,17 @@ func SetStack(newStack string) error {
 	return space.SetStack(newStack)
 }
 
-func List() ([]Stack, error) {
-	stacks, err := FindStacks(BaseDir)
+func List(opts ...Option) ([]Stack, error) {
+	o := newOptions(opts)
+
+	stacks, err := FindStacks(o.baseDir)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []Stack
 	for _, stack := range stacks {
-		stack, err := ReadStack(stack)
+		stack, err := ReadStack(stack, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -107,6 +114,73 @@ func List() ([]Stack, error) {
 	return result, nil
 }
 
+// ListStacksAllDirs concurrently finds the stacks of all directories and returns them by directory
+func ListStacksAllDirs(dirs []string) (map[string][]string, error) {
+	result := make(map[string][]string, len(dirs))
+	var mu sync.Mutex
+
+	g := errgroup.Group{}
+	for _, dir := range dirs {
+		dir := dir
+		g.Go(func() error {
+			stacks, err := FindStacks(dir)
+			if err != nil {
+				return fmt.Errorf("could not find stacks in %s: %w", dir, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result[dir] = stacks
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readAllStackConfigsWorkers limits the number of stack files that are read concurrently
+const readAllStackConfigsWorkers = 8
+
+// ReadAllStackConfigs reads the config of all stacks of the project concurrently. Stacks that could not be read are
+// missing from the result; their errors are joined into the returned error
+func ReadAllStackConfigs(opts ...Option) (map[string]*PulumiStackYaml, error) {
+	o := newOptions(opts)
+
+	stacks, err := FindStacks(o.baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*PulumiStackYaml, len(stacks))
+	var errs []error
+	var mu sync.Mutex
+
+	g := errgroup.Group{}
+	g.SetLimit(readAllStackConfigsWorkers)
+	for _, name := range stacks {
+		name := name
+		g.Go(func() error {
+			config, err := ReadStackYaml(name, opts...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not read stack %s: %w", name, err))
+				return nil
+			}
+			result[name] = config
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return result, errors.Join(errs...)
+}
+
 func FindStacks(dir string) ([]string, error) {
 	files, err := os.ReadDir(dir)
 	if err != nil {
@@ -130,12 +204,62 @@ func FindStacks(dir string) ([]string, error) {
 	return stacks, nil
 }
 
-func ReadStack(name string) (*Stack, error) {
-	project, err := Project()
+// CreateStack creates a new stack file and returns its path. If PULUMI_CONFIG_PASSPHRASE is set, a new encryption salt is generated.
+// The file is created exclusively, so an existing stack file is never overwritten, not even by a concurrent CreateStack
+func CreateStack(name string, opts ...Option) (string, error) {
+	y := &PulumiStackYaml{
+		Config: map[string]string{},
+	}
+
+	if os.Getenv("PULUMI_CONFIG_PASSPHRASE") != "" {
+		salt, err := GenerateSalt()
+		if err != nil {
+			return "", err
+		}
+		y.Encryptionsalt = salt
+	}
+
+	data, err := encodeStackYaml(y)
+	if err != nil {
+		return "", err
+	}
+
+	stackFile := newOptions(opts).stackFile(name)
+	f, err := os.OpenFile(stackFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("stack %s already exists", name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(stackFile)
+		return "", err
+	}
+
+	return stackFile, nil
+}
+
+// DeleteStack removes the stack file of the given stack
+func DeleteStack(name string, opts ...Option) error {
+	if !StackExists(name, opts...) {
+		return fmt.Errorf("stack %s not found", name)
+	}
+
+	return os.Remove(newOptions(opts).stackFile(name))
+}
+
+func ReadStack(name string, opts ...Option) (*Stack, error) {
+	project, err := Project(opts...)
 	if err != nil {
 		return nil, err
 	}
-	configuration, err := ReadStackYaml(name)
+	configuration, err := ReadStackYaml(name, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -149,9 +273,9 @@ func ReadStack(name string) (*Stack, error) {
 	return stack, nil
 }
 
-func ReadStackYaml(name string) (*PulumiStackYaml, error) {
+func ReadStackYaml(name string, opts ...Option) (*PulumiStackYaml, error) {
 	// open file
-	data, err := os.ReadFile(path.Join(BaseDir, fmt.Sprintf("Pulumi.%s.yaml", name)))
+	data, err := os.ReadFile(newOptions(opts).stackFile(name))
 	if err != nil {
 		return nil, err
 	}
@@ -164,35 +288,86 @@ func ReadStackYaml(name string) (*PulumiStackYaml, error) {
 	return p, nil
 }
 
-func WriteStackYaml(name string, stack *PulumiStackYaml) error {
-	var b bytes.Buffer
-	yamlEncoder := yaml.NewEncoder(&b)
-	yamlEncoder.SetIndent(2)
-	err := yamlEncoder.Encode(&stack)
+func WriteStackYaml(name string, stack *PulumiStackYaml, opts ...Option) error {
+	data, err := encodeStackYaml(stack)
 	if err != nil {
 		return err
 	}
 
-	err = os.WriteFile(path.Join(BaseDir, fmt.Sprintf("Pulumi.%s.yaml", name)), b.Bytes(), 0644)
+	err = os.WriteFile(newOptions(opts).stackFile(name), data, 0644)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func IsPulumiProject() bool {
-	file := path.Join(BaseDir, "Pulumi.yaml")
+// writeStackYamlAtomic writes the stack file to a temporary file first and renames it afterwards,
+// so readers never see a partially written stack file
+func writeStackYamlAtomic(name string, stack *PulumiStackYaml, opts ...Option) error {
+	o := newOptions(opts)
+
+	data, err := encodeStackYaml(stack)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(o.baseDir, fmt.Sprintf(".Pulumi.%s.yaml.*", name))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), o.stackFile(name))
+}
+
+func encodeStackYaml(stack *PulumiStackYaml) ([]byte, error) {
+	var b bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&b)
+	yamlEncoder.SetIndent(2)
+	err := yamlEncoder.Encode(&stack)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// StackExists returns true if the stack file of the given stack exists
+func StackExists(name string, opts ...Option) bool {
+	file := newOptions(opts).stackFile(name)
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+// TouchStack sets the modification time of the stack file to now without changing its content
+func TouchStack(name string, opts ...Option) error {
+	now := time.Now()
+	return os.Chtimes(newOptions(opts).stackFile(name), now, now)
+}
+
+func IsPulumiProject(opts ...Option) bool {
+	file := newOptions(opts).projectFile()
 	_, err := os.Stat(file)
 	return err == nil
 }
 
-func Project() (*PulumiYaml, error) {
-	if !IsPulumiProject() {
+func Project(opts ...Option) (*PulumiYaml, error) {
+	if !IsPulumiProject(opts...) {
 		return nil, errors.New("not a pulumi project")
 	}
 
 	// open file
-	data, err := os.ReadFile(path.Join(BaseDir, "Pulumi.yaml"))
+	data, err := os.ReadFile(newOptions(opts).projectFile())
 	if err != nil {
 		return nil, err
 	}
@@ -207,8 +382,8 @@ func Project() (*PulumiYaml, error) {
 	return p, err
 }
 
-func ProjectName() (string, error) {
-	p, err := Project()
+func ProjectName(opts ...Option) (string, error) {
+	p, err := Project(opts...)
 	if err != nil {
 		return "", errors.Join(err, errors.New("could not read project name"))
 	}
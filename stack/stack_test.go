@@ -0,0 +1,160 @@
+package stack
+
+import (
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateStack(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "foo")
+
+	file, err := CreateStack("dev", opt)
+	require.NoError(t, err)
+	require.FileExists(t, file)
+	require.True(t, StackExists("dev", opt))
+
+	y, err := ReadStackYaml("dev", opt)
+	require.NoError(t, err)
+	require.NotEmpty(t, y.Encryptionsalt)
+
+	_, err = CreateStack("dev", opt)
+	require.Error(t, err)
+}
+
+func TestCreateStackConcurrently(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = CreateStack("dev", opt)
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		require.ErrorContains(t, err, "stack dev already exists")
+	}
+	require.Equal(t, 1, created)
+}
+
+func TestCreateStackWithoutPassphrase(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "")
+
+	_, err := CreateStack("dev", opt)
+	require.NoError(t, err)
+
+	y, err := ReadStackYaml("dev", opt)
+	require.NoError(t, err)
+	require.Empty(t, y.Encryptionsalt)
+}
+
+func TestDeleteStack(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "")
+
+	_, err := CreateStack("dev", opt)
+	require.NoError(t, err)
+
+	err = DeleteStack("dev", opt)
+	require.NoError(t, err)
+	require.False(t, StackExists("dev", opt))
+
+	err = DeleteStack("dev", opt)
+	require.Error(t, err)
+}
+
+func TestListStacksAllDirs(t *testing.T) {
+	dirs := []string{t.TempDir(), t.TempDir()}
+	for i, names := range [][]string{{"dev", "prod"}, {"test"}} {
+		for _, name := range names {
+			err := os.WriteFile(path.Join(dirs[i], "Pulumi."+name+".yaml"), []byte("config: {}\n"), 0644)
+			require.NoError(t, err)
+		}
+		err := os.WriteFile(path.Join(dirs[i], "Pulumi.yaml"), []byte("name: demo\n"), 0644)
+		require.NoError(t, err)
+	}
+
+	got, err := ListStacksAllDirs(dirs)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"dev", "prod"}, got[dirs[0]])
+	require.Equal(t, []string{"test"}, got[dirs[1]])
+
+	_, err = ListStacksAllDirs(append(dirs, path.Join(dirs[0], "missing")))
+	require.Error(t, err)
+}
+
+func TestReadAllStackConfigs(t *testing.T) {
+	dir := t.TempDir()
+	opt := WithBaseDir(dir)
+
+	for _, name := range []string{"dev", "prod"} {
+		err := WriteStackYaml(name, &PulumiStackYaml{
+			Config: map[string]string{"app:env": name},
+		}, opt)
+		require.NoError(t, err)
+	}
+	err := os.WriteFile(path.Join(dir, "Pulumi.broken.yaml"), []byte("config: [\n"), 0644)
+	require.NoError(t, err)
+
+	configs, err := ReadAllStackConfigs(opt)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "broken")
+	require.Len(t, configs, 2)
+	require.Equal(t, "prod", configs["prod"].Config["app:env"])
+}
+
+func TestWithBaseDir(t *testing.T) {
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "")
+	a := WithBaseDir(t.TempDir())
+	b := WithBaseDir(t.TempDir())
+
+	_, err := CreateStack("dev", a)
+	require.NoError(t, err)
+
+	require.True(t, StackExists("dev", a))
+	require.False(t, StackExists("dev", b))
+	require.False(t, StackExists("dev"))
+}
+
+func TestTouchStack(t *testing.T) {
+	dir := t.TempDir()
+	opt := WithBaseDir(dir)
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{Config: map[string]string{"app:name": "demo"}}, opt)
+	require.NoError(t, err)
+	file := path.Join(dir, "Pulumi.dev.yaml")
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(file, old, old))
+	before, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	err = TouchStack("dev", opt)
+	require.NoError(t, err)
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), info.ModTime(), time.Minute)
+	after, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+
+	err = TouchStack("missing", opt)
+	require.Error(t, err)
+}
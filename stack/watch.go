@@ -0,0 +1,71 @@
+package stack
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// watchDebounce is the time a stack file must be unchanged before WatchStackConfig reports it, so rapid successive
+// writes are reported once
+const watchDebounce = 200 * time.Millisecond
+
+// WatchStackConfig polls the modification time of the stack file every interval and sends the re-read config to ch
+// whenever the file changed. Calling the returned cancel func stops the watch and returns once no more values are sent
+func WatchStackConfig(stackName string, interval time.Duration, ch chan<- *PulumiStackYaml, opts ...Option) (func(), error) {
+	file := newOptions(opts).stackFile(stackName)
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		lastModTime := info.ModTime()
+		var changedAt time.Time
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			info, err := os.Stat(file)
+			if err != nil {
+				// the file may be replaced right now; try again on the next tick
+				continue
+			}
+			if !info.ModTime().Equal(lastModTime) {
+				lastModTime = info.ModTime()
+				changedAt = time.Now()
+				continue
+			}
+			if changedAt.IsZero() || time.Since(changedAt) < watchDebounce {
+				continue
+			}
+			changedAt = time.Time{}
+
+			y, err := ReadStackYaml(stackName, opts...)
+			if err != nil {
+				continue
+			}
+			select {
+			case ch <- y:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancelCtx()
+		<-done
+	}, nil
+}
@@ -0,0 +1,42 @@
+package stack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchStackConfig(t *testing.T) {
+	opt := WithBaseDir(t.TempDir())
+
+	err := WriteStackYaml("dev", &PulumiStackYaml{Config: map[string]string{"app:version": "1"}}, opt)
+	require.NoError(t, err)
+
+	ch := make(chan *PulumiStackYaml, 10)
+	cancel, err := WatchStackConfig("dev", 20*time.Millisecond, ch, opt)
+	require.NoError(t, err)
+	defer cancel()
+
+	err = WriteStackYaml("dev", &PulumiStackYaml{Config: map[string]string{"app:version": "2"}}, opt)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	err = WriteStackYaml("dev", &PulumiStackYaml{Config: map[string]string{"app:version": "3"}}, opt)
+	require.NoError(t, err)
+
+	select {
+	case y := <-ch:
+		require.Equal(t, "3", y.Config["app:version"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("no config change received")
+	}
+
+	select {
+	case y := <-ch:
+		t.Fatalf("unexpected second change: %v", y.Config)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	_, err = WatchStackConfig("missing", time.Second, ch, opt)
+	require.Error(t, err)
+}
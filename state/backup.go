@@ -0,0 +1,62 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+)
+
+// backupTimeFormat is the timestamp format used in the file names of backups
+const backupTimeFormat = "20060102-150405.000"
+
+// BackupToDir copies the state file to <dir>/<name>-<timestamp>.json and returns the path of the backup. The checksum
+// of the backup is stored next to it in a .sha256 file
+func (s *State) BackupToDir(dir string) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	backupPath := path.Join(dir, fmt.Sprintf("%s-%s.json", s.Name, time.Now().UTC().Format(backupTimeFormat)))
+	err = copyFile(s.Path, backupPath)
+	if err != nil {
+		return "", err
+	}
+
+	// the checksum is computed from the written backup, so it also detects a bad copy
+	checksum, err := fileChecksum(backupPath)
+	if err != nil {
+		return "", err
+	}
+	err = os.WriteFile(backupPath+".sha256", []byte(checksum+"\n"), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return backupPath, nil
+}
+
+// RestoreFromBackup copies the backup back to the local state file of the given state, overwriting it if it exists
+func RestoreFromBackup(backupPath, stateName string) error {
+	stateDir, err := stateDir()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(stateDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	return copyFile(backupPath, path.Join(stateDir, stateName+".json"))
+}
+
+func copyFile(src, dest string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dest, data, 0644)
+}
@@ -0,0 +1,47 @@
+package state
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	stacksDir := path.Join(home, ".pulumi", "stacks")
+	require.NoError(t, os.MkdirAll(stacksDir, 0755))
+	statePath := path.Join(stacksDir, "dev.json")
+	require.NoError(t, os.WriteFile(statePath, []byte(graphState), 0644))
+
+	s, err := GetState("dev")
+	require.NoError(t, err)
+
+	backupDir := path.Join(t.TempDir(), "backups")
+	backupPath, err := s.BackupToDir(backupDir)
+	require.NoError(t, err)
+	require.Equal(t, backupDir, path.Dir(backupPath))
+	require.True(t, strings.HasPrefix(path.Base(backupPath), "dev-"))
+
+	checksum, err := os.ReadFile(backupPath + ".sha256")
+	require.NoError(t, err)
+	require.NoError(t, s.VerifyChecksum(strings.TrimSpace(string(checksum))))
+	backup := &State{Path: backupPath}
+	require.NoError(t, backup.VerifyChecksum(strings.TrimSpace(string(checksum))))
+
+	require.NoError(t, os.WriteFile(statePath, []byte("{}"), 0644))
+
+	err = RestoreFromBackup(backupPath, "dev")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	require.Equal(t, graphState, string(data))
+
+	err = RestoreFromBackup(path.Join(backupDir, "missing.json"), "dev")
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrChecksumMismatch is returned by VerifyChecksum when the state does not match the expected checksum
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ComputeChecksum returns the hex encoded SHA-256 of the canonical JSON of the state file, i.e. with sorted keys and
+// without insignificant whitespace, so reformatting the file does not change the checksum
+func (s *State) ComputeChecksum() (string, error) {
+	return fileChecksum(s.Path)
+}
+
+// fileChecksum returns the hex encoded SHA-256 of the canonical JSON of the state file at filePath
+func fileChecksum(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256(canonical)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// VerifyChecksum returns ErrChecksumMismatch if the checksum of the state differs from expected
+func (s *State) VerifyChecksum(expected string) error {
+	actual, err := s.ComputeChecksum()
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers as they are instead of converting them to float64
+	dec.UseNumber()
+
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	// encoding/json sorts map keys and writes no whitespace
+	return json.Marshal(v)
+}
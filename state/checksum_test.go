@@ -0,0 +1,26 @@
+package state
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeChecksum(t *testing.T) {
+	a := writeState(t, "a", `{"checkpoint": {"latest": {"resources": [], "version": 3}}}`)
+	b := writeState(t, "b", "{\n    \"checkpoint\": {\n        \"latest\": {\n            \"version\": 3,\n            \"resources\": []\n        }\n    }\n}\n")
+
+	checksumA, err := a.ComputeChecksum()
+	require.NoError(t, err)
+	require.Len(t, checksumA, 64)
+
+	checksumB, err := b.ComputeChecksum()
+	require.NoError(t, err)
+	require.Equal(t, checksumA, checksumB)
+
+	require.NoError(t, a.VerifyChecksum(checksumA))
+
+	require.NoError(t, os.WriteFile(a.Path, []byte(`{"checkpoint": {"latest": {"resources": [], "version": 4}}}`), 0644))
+	require.ErrorIs(t, a.VerifyChecksum(checksumA), ErrChecksumMismatch)
+}
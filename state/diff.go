@@ -0,0 +1,129 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ResourceAdded     = "added"
+	ResourceRemoved   = "removed"
+	ResourceChanged   = "changed"
+	ResourceUnchanged = "unchanged"
+)
+
+// ResourceDiff describes the change of a single resource between two states
+type ResourceDiff struct {
+	URN    string
+	Type   string
+	Change string
+	// Outputs contains the changed outputs in a unified diff style ("- key: old", "+ key: new")
+	Outputs []string
+}
+
+// DiffStates compares the resources of two states. Resources are matched by their URN without the stack name,
+// so states of different stacks of the same project can be compared
+func DiffStates(a, b *State) ([]ResourceDiff, error) {
+	resourcesA, err := a.Resources()
+	if err != nil {
+		return nil, err
+	}
+	resourcesB, err := b.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	byKeyA := resourcesByKey(resourcesA)
+	byKeyB := resourcesByKey(resourcesB)
+
+	var diffs []ResourceDiff
+	for key, resourceA := range byKeyA {
+		resourceB, ok := byKeyB[key]
+		if !ok {
+			diffs = append(diffs, ResourceDiff{
+				URN:    resourceA.URN,
+				Type:   resourceA.Type,
+				Change: ResourceRemoved,
+			})
+			continue
+		}
+
+		outputs := diffOutputs(resourceA.Outputs, resourceB.Outputs)
+		change := ResourceUnchanged
+		if len(outputs) > 0 {
+			change = ResourceChanged
+		}
+		diffs = append(diffs, ResourceDiff{
+			URN:     resourceB.URN,
+			Type:    resourceB.Type,
+			Change:  change,
+			Outputs: outputs,
+		})
+	}
+	for key, resourceB := range byKeyB {
+		if _, ok := byKeyA[key]; !ok {
+			diffs = append(diffs, ResourceDiff{
+				URN:    resourceB.URN,
+				Type:   resourceB.Type,
+				Change: ResourceAdded,
+			})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return resourceKey(diffs[i].URN) < resourceKey(diffs[j].URN)
+	})
+
+	return diffs, nil
+}
+
+func resourcesByKey(resources []Resource) map[string]Resource {
+	result := make(map[string]Resource, len(resources))
+	for _, resource := range resources {
+		result[resourceKey(resource.URN)] = resource
+	}
+	return result
+}
+
+// resourceKey strips the stack from an URN: urn:pulumi:<stack>::<project>::<type>::<name> becomes <project>::<type>::<name>
+func resourceKey(urn string) string {
+	parts := strings.SplitN(urn, "::", 2)
+	if len(parts) != 2 {
+		return urn
+	}
+	return parts[1]
+}
+
+func diffOutputs(outputsA, outputsB map[string]json.RawMessage) []string {
+
+	keys := map[string]bool{}
+	for key := range outputsA {
+		keys[key] = true
+	}
+	for key := range outputsB {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	var lines []string
+	for _, key := range sortedKeys {
+		valueA, okA := outputsA[key]
+		valueB, okB := outputsB[key]
+		if okA && okB && string(valueA) == string(valueB) {
+			continue
+		}
+		if okA {
+			lines = append(lines, fmt.Sprintf("- %s: %s", key, valueA))
+		}
+		if okB {
+			lines = append(lines, fmt.Sprintf("+ %s: %s", key, valueB))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeState(t *testing.T, name, content string) *State {
+	p := path.Join(t.TempDir(), name+".json")
+	err := os.WriteFile(p, []byte(content), 0644)
+	require.NoError(t, err)
+	return &State{
+		Name:     name,
+		FileName: name + ".json",
+		Path:     p,
+	}
+}
+
+func TestDiffStates(t *testing.T) {
+	a := writeState(t, "dev", `{"checkpoint":{"latest":{"resources":[
+		{"urn":"urn:pulumi:dev::demo::pulumi:pulumi:Stack::demo-dev","type":"pulumi:pulumi:Stack","outputs":{"name":"demo"}},
+		{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm1","type":"kubernetes:core/v1:ConfigMap","outputs":{"data":{"a":"1"}}},
+		{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm2","type":"kubernetes:core/v1:ConfigMap","outputs":{}}
+	]}}}`)
+	b := writeState(t, "prod", `{"checkpoint":{"latest":{"resources":[
+		{"urn":"urn:pulumi:prod::demo::pulumi:pulumi:Stack::demo-dev","type":"pulumi:pulumi:Stack","outputs":{"name":"demo"}},
+		{"urn":"urn:pulumi:prod::demo::kubernetes:core/v1:ConfigMap::cm1","type":"kubernetes:core/v1:ConfigMap","outputs":{"data":{"a":"2"}}},
+		{"urn":"urn:pulumi:prod::demo::kubernetes:core/v1:Secret::s1","type":"kubernetes:core/v1:Secret","outputs":{}}
+	]}}}`)
+
+	diffs, err := DiffStates(a, b)
+	require.NoError(t, err)
+	require.Len(t, diffs, 4)
+
+	changes := map[string]ResourceDiff{}
+	for _, diff := range diffs {
+		changes[resourceKey(diff.URN)] = diff
+	}
+
+	require.Equal(t, ResourceUnchanged, changes["demo::pulumi:pulumi:Stack::demo-dev"].Change)
+	require.Equal(t, ResourceRemoved, changes["demo::kubernetes:core/v1:ConfigMap::cm2"].Change)
+	require.Equal(t, ResourceAdded, changes["demo::kubernetes:core/v1:Secret::s1"].Change)
+
+	changed := changes["demo::kubernetes:core/v1:ConfigMap::cm1"]
+	require.Equal(t, ResourceChanged, changed.Change)
+	require.Equal(t, []string{`- data: {"a":"1"}`, `+ data: {"a":"2"}`}, changed.Outputs)
+}
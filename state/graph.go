@@ -0,0 +1,73 @@
+package state
+
+import "fmt"
+
+// ResourceDependencyGraph returns a map from each resource URN to the URNs it depends on
+func (s *State) ResourceDependencyGraph() (map[string][]string, error) {
+	resources, err := s.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	graph := make(map[string][]string, len(resources))
+	for _, resource := range resources {
+		graph[resource.URN] = resource.Dependencies
+	}
+	return graph, nil
+}
+
+// TransitiveDependencies returns all direct and indirect dependencies of the resource in breadth-first order
+func (s *State) TransitiveDependencies(urn string) ([]string, error) {
+	graph, err := s.ResourceDependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := graph[urn]; !ok {
+		return nil, fmt.Errorf("resource %s not found in state %s", urn, s.Name)
+	}
+
+	return transitiveDependencies(graph, urn), nil
+}
+
+func transitiveDependencies(graph map[string][]string, urn string) []string {
+	result := []string{}
+	visited := map[string]bool{urn: true}
+	queue := []string{urn}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependency := range graph[current] {
+			if visited[dependency] {
+				continue
+			}
+			visited[dependency] = true
+			result = append(result, dependency)
+			queue = append(queue, dependency)
+		}
+	}
+	return result
+}
+
+// OrphanedResources returns the URNs of resources that depend on resources which are not part of the state
+func (s *State) OrphanedResources() ([]string, error) {
+	resources, err := s.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		known[resource.URN] = true
+	}
+
+	orphaned := []string{}
+	for _, resource := range resources {
+		for _, dependency := range resource.Dependencies {
+			if !known[dependency] {
+				orphaned = append(orphaned, resource.URN)
+				break
+			}
+		}
+	}
+	return orphaned, nil
+}
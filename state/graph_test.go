@@ -0,0 +1,71 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const graphState = `{"checkpoint":{"latest":{"resources":[
+	{"urn":"urn:pulumi:dev::demo::pulumi:pulumi:Stack::demo-dev","type":"pulumi:pulumi:Stack"},
+	{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns","type":"kubernetes:core/v1:Namespace"},
+	{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm","type":"kubernetes:core/v1:ConfigMap",
+		"dependencies":["urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns"]},
+	{"urn":"urn:pulumi:dev::demo::kubernetes:apps/v1:Deployment::app","type":"kubernetes:apps/v1:Deployment",
+		"dependencies":["urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm","urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns"]}
+]}}}`
+
+func TestResourceDependencyGraph(t *testing.T) {
+	s := writeState(t, "dev", graphState)
+
+	graph, err := s.ResourceDependencyGraph()
+	require.NoError(t, err)
+	require.Len(t, graph, 4)
+	require.Empty(t, graph["urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns"])
+	require.Equal(t, []string{"urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns"}, graph["urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm"])
+}
+
+func TestTransitiveDependencies(t *testing.T) {
+	s := writeState(t, "dev", graphState)
+
+	deps, err := s.TransitiveDependencies("urn:pulumi:dev::demo::kubernetes:apps/v1:Deployment::app")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm",
+		"urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns",
+	}, deps)
+
+	deps, err = s.TransitiveDependencies("urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::ns")
+	require.NoError(t, err)
+	require.Empty(t, deps)
+
+	_, err = s.TransitiveDependencies("urn:pulumi:dev::demo::kubernetes:core/v1:Secret::missing")
+	require.Error(t, err)
+}
+
+func TestTransitiveDependenciesCycle(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	}
+	require.Equal(t, []string{"b", "c"}, transitiveDependencies(graph, "a"))
+}
+
+func TestOrphanedResources(t *testing.T) {
+	s := writeState(t, "dev", graphState)
+
+	orphaned, err := s.OrphanedResources()
+	require.NoError(t, err)
+	require.Empty(t, orphaned)
+
+	s = writeState(t, "broken", `{"checkpoint":{"latest":{"resources":[
+		{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm","type":"kubernetes:core/v1:ConfigMap",
+			"dependencies":["urn:pulumi:dev::demo::kubernetes:core/v1:Namespace::deleted"]},
+		{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:Secret::secret","type":"kubernetes:core/v1:Secret"}
+	]}}}`)
+
+	orphaned, err = s.OrphanedResources()
+	require.NoError(t, err)
+	require.Equal(t, []string{"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm"}, orphaned)
+}
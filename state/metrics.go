@@ -0,0 +1,77 @@
+package state
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// StateMetrics contains aggregate statistics about a state
+type StateMetrics struct {
+	TotalResources int
+	// ResourcesByProvider counts the resources per package of their type token, e.g. "kubernetes"
+	ResourcesByProvider map[string]int
+	ResourcesByType     map[string]int
+	// MaxDependencyDepth is the length of the longest dependency chain
+	MaxDependencyDepth int
+	LastModified       time.Time
+}
+
+// Metrics computes aggregate statistics about the state
+func (s *State) Metrics() (*StateMetrics, error) {
+	resources, err := s.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &StateMetrics{
+		TotalResources:      len(resources),
+		ResourcesByProvider: map[string]int{},
+		ResourcesByType:     map[string]int{},
+		LastModified:        info.ModTime(),
+	}
+
+	graph := make(map[string][]string, len(resources))
+	for _, resource := range resources {
+		provider, _, _ := strings.Cut(resource.Type, ":")
+		metrics.ResourcesByProvider[provider]++
+		metrics.ResourcesByType[resource.Type]++
+		graph[resource.URN] = resource.Dependencies
+	}
+
+	depths := map[string]int{}
+	for urn := range graph {
+		depth := dependencyDepth(graph, urn, depths, map[string]bool{})
+		if depth > metrics.MaxDependencyDepth {
+			metrics.MaxDependencyDepth = depth
+		}
+	}
+
+	return metrics, nil
+}
+
+// dependencyDepth returns the length of the longest dependency chain starting at urn. Cycles are not followed
+func dependencyDepth(graph map[string][]string, urn string, depths map[string]int, visiting map[string]bool) int {
+	if depth, ok := depths[urn]; ok {
+		return depth
+	}
+	if visiting[urn] {
+		return 0
+	}
+	visiting[urn] = true
+	defer delete(visiting, urn)
+
+	depth := 0
+	for _, dependency := range graph[urn] {
+		if d := dependencyDepth(graph, dependency, depths, visiting) + 1; d > depth {
+			depth = d
+		}
+	}
+	depths[urn] = depth
+	return depth
+}
@@ -0,0 +1,19 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	s := writeState(t, "dev", graphState)
+
+	metrics, err := s.Metrics()
+	require.NoError(t, err)
+	require.Equal(t, 4, metrics.TotalResources)
+	require.Equal(t, map[string]int{"pulumi": 1, "kubernetes": 3}, metrics.ResourcesByProvider)
+	require.Equal(t, 1, metrics.ResourcesByType["kubernetes:apps/v1:Deployment"])
+	require.Equal(t, 2, metrics.MaxDependencyDepth)
+	require.False(t, metrics.LastModified.IsZero())
+}
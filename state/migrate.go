@@ -0,0 +1,49 @@
+package state
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// MigrateLocalState copies the state file to the file backend in destDir, i.e. to <destDir>/.pulumi/stacks/<name>.json,
+// and makes file://<destDir> the current backend in ~/.pulumi/credentials.json, like "pulumi login file://<destDir>"
+// does. A backend set by PULUMI_BACKEND_URL or by backend.url in Pulumi.yaml still takes precedence.
+// The source state is left untouched
+func MigrateLocalState(srcStateName, destDir string) error {
+	src, err := GetState(srcStateName)
+	if err != nil {
+		return err
+	}
+
+	absDestDir, err := filepath.Abs(destDir)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(src.Path)
+	if err != nil {
+		return err
+	}
+
+	destStateDir := path.Join(absDestDir, ".pulumi", "stacks")
+	err = os.MkdirAll(destStateDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	destPath := path.Join(destStateDir, src.FileName)
+	err = os.WriteFile(destPath, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = os.Chtimes(destPath, src.ModTime, src.ModTime)
+	if err != nil {
+		return err
+	}
+
+	return workspace.StoreAccount("file://"+absDestDir, workspace.Account{}, true)
+}
@@ -0,0 +1,36 @@
+package state
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateLocalState(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PULUMI_HOME", path.Join(home, ".pulumi"))
+
+	stacksDir := path.Join(home, ".pulumi", "stacks")
+	require.NoError(t, os.MkdirAll(stacksDir, 0755))
+	require.NoError(t, os.WriteFile(path.Join(stacksDir, "dev.json"), []byte(graphState), 0644))
+
+	destDir := path.Join(t.TempDir(), "backend")
+
+	err := MigrateLocalState("dev", destDir)
+	require.NoError(t, err)
+
+	credentials, err := workspace.GetStoredCredentials()
+	require.NoError(t, err)
+	require.Equal(t, "file://"+destDir, credentials.Current)
+
+	data, err := os.ReadFile(path.Join(destDir, ".pulumi", "stacks", "dev.json"))
+	require.NoError(t, err)
+	require.Equal(t, graphState, string(data))
+
+	err = MigrateLocalState("missing", destDir)
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tidwall/gjson"
+)
+
+// SetOutput sets the stack output key to value in the state file
+func (s *State) SetOutput(key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return s.updateOutputs(func(outputs map[string]json.RawMessage) error {
+		outputs[key] = raw
+		return nil
+	})
+}
+
+// KeyNotFoundError is returned when a key does not exist in the stack outputs
+type KeyNotFoundError struct {
+	Key string
+}
+
+func (e *KeyNotFoundError) Error() string {
+	return fmt.Sprintf("output %s not found", e.Key)
+}
+
+// RemoveOutput removes the stack output key from the state file
+func (s *State) RemoveOutput(key string) error {
+	return s.updateOutputs(func(outputs map[string]json.RawMessage) error {
+		if _, ok := outputs[key]; !ok {
+			return &KeyNotFoundError{Key: key}
+		}
+		delete(outputs, key)
+		return nil
+	})
+}
+
+// updateOutputs passes the outputs of the pulumi:pulumi:Stack resource to update and writes the changed outputs back
+// to the state file. Only the stack resource is re-encoded; the rest of the file keeps its key order
+func (s *State) updateOutputs(update func(outputs map[string]json.RawMessage) error) error {
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return err
+	}
+
+	var stackResource gjson.Result
+	for _, resource := range gjson.GetBytes(data, "checkpoint.latest.resources").Array() {
+		if resource.Get("type").String() == "pulumi:pulumi:Stack" {
+			stackResource = resource
+			break
+		}
+	}
+	if !stackResource.Exists() || stackResource.Index == 0 {
+		return errors.New("stack resource not found")
+	}
+
+	var resource map[string]json.RawMessage
+	err = json.Unmarshal([]byte(stackResource.Raw), &resource)
+	if err != nil {
+		return err
+	}
+	outputs := map[string]json.RawMessage{}
+	if raw, ok := resource["outputs"]; ok {
+		err = json.Unmarshal(raw, &outputs)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = update(outputs)
+	if err != nil {
+		return err
+	}
+
+	resource["outputs"], err = json.Marshal(outputs)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+
+	var updated []byte
+	updated = append(updated, data[:stackResource.Index]...)
+	updated = append(updated, raw...)
+	updated = append(updated, data[stackResource.Index+len(stackResource.Raw):]...)
+
+	var b bytes.Buffer
+	err = json.Indent(&b, updated, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.Path, b.Bytes(), info.Mode().Perm())
+}
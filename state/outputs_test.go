@@ -0,0 +1,57 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOutput(t *testing.T) {
+	s := writeState(t, "dev", graphState)
+
+	err := s.SetOutput("commit", "abc123")
+	require.NoError(t, err)
+	err = s.SetOutput("replicas", 3)
+	require.NoError(t, err)
+
+	var commit string
+	err = s.GetOutput("commit", &commit)
+	require.NoError(t, err)
+	require.Equal(t, "abc123", commit)
+
+	var replicas int
+	err = s.GetOutput("replicas", &replicas)
+	require.NoError(t, err)
+	require.Equal(t, 3, replicas)
+
+	resources, err := s.Resources()
+	require.NoError(t, err)
+	require.Len(t, resources, 4)
+	require.Equal(t, "urn:pulumi:dev::demo::kubernetes:apps/v1:Deployment::app", resources[3].URN)
+}
+
+func TestSetOutputWithoutStackResource(t *testing.T) {
+	s := writeState(t, "dev", `{"checkpoint":{"latest":{"resources":[]}}}`)
+
+	err := s.SetOutput("commit", "abc123")
+	require.Error(t, err)
+}
+
+func TestRemoveOutput(t *testing.T) {
+	s := writeState(t, "dev", graphState)
+
+	err := s.SetOutput("commit", "abc123")
+	require.NoError(t, err)
+
+	err = s.RemoveOutput("commit")
+	require.NoError(t, err)
+
+	keys, err := s.OutputKeys()
+	require.NoError(t, err)
+	require.NotContains(t, keys, "commit")
+
+	err = s.RemoveOutput("commit")
+	var keyNotFound *KeyNotFoundError
+	require.ErrorAs(t, err, &keyNotFound)
+	require.Equal(t, "commit", keyNotFound.Key)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -51,6 +53,16 @@ func GetState(name string) (*State, error) {
 	return &state, nil
 }
 
+// RemoveState deletes the local state file of the given state
+func RemoveState(name string) error {
+	state, err := GetState(name)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(state.Path)
+}
+
 func GetStates() (map[string]State, error) {
 	stateDir, err := stateDir()
 	if err != nil {
@@ -77,6 +89,121 @@ type State struct {
 	ModTime  time.Time
 }
 
+func (s *State) resources() ([]gjson.Result, error) {
+	jsonB, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return gjson.GetBytes(jsonB, "checkpoint.latest.resources").Array(), nil
+}
+
+// Resource is a single resource of a state
+type Resource struct {
+	URN          string                     `json:"urn"`
+	Type         string                     `json:"type"`
+	ID           string                     `json:"id"`
+	Inputs       map[string]json.RawMessage `json:"inputs"`
+	Outputs      map[string]json.RawMessage `json:"outputs"`
+	Dependencies []string                   `json:"dependencies"`
+}
+
+// Resources returns all resources of the state
+func (s *State) Resources() ([]Resource, error) {
+	raw, err := s.resources()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Resource, 0, len(raw))
+	for _, r := range raw {
+		var resource Resource
+		err = json.Unmarshal([]byte(r.Raw), &resource)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
+
+// ResourceNotFoundError is returned when no resource of a state has the requested ID
+type ResourceNotFoundError struct {
+	ID string
+}
+
+func (e *ResourceNotFoundError) Error() string {
+	return fmt.Sprintf("resource with id %s not found", e.ID)
+}
+
+// GetResourceByID returns the first resource whose cloud provider ID matches id
+func (s *State) GetResourceByID(id string) (*Resource, error) {
+	resources, err := s.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resources {
+		if resources[i].ID == id {
+			return &resources[i], nil
+		}
+	}
+	return nil, &ResourceNotFoundError{ID: id}
+}
+
+// ResourcesRaw returns all resources of the state for full gjson access
+func (s *State) ResourcesRaw() ([]map[string]gjson.Result, error) {
+	resources, err := s.resources()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]gjson.Result, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, resource.Map())
+	}
+	return result, nil
+}
+
+// FilterResourcesByType returns the resources whose type matches the pattern; see MatchType
+func FilterResourcesByType(resources []Resource, pattern string) []Resource {
+	var result []Resource
+	for _, resource := range resources {
+		if MatchType(pattern, resource.Type) {
+			result = append(result, resource)
+		}
+	}
+	return result
+}
+
+// MatchType reports whether the type token matches the pattern. The pattern may contain the wildcards
+// '*' (any sequence of characters, including ':' and '/') and '?' (any single character),
+// e.g. "kubernetes:core/v1:*" or "*:ConfigMap"
+func MatchType(pattern, typeToken string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == typeToken
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("^" + expr + "$").MatchString(typeToken)
+}
+
+// CountByType returns the number of resources in the state per resource type
+func (s *State) CountByType() (map[string]int, error) {
+	resources, err := s.resources()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, resource := range resources {
+		counts[resource.Get("type").String()]++
+	}
+	return counts, nil
+}
+
 func (s *State) Outputs() (map[string]gjson.Result, error) {
 
 	jsonB, err := os.ReadFile(s.Path)
@@ -96,6 +223,17 @@ func (s *State) Outputs() (map[string]gjson.Result, error) {
 	return r, nil
 }
 
+// SecretSig is the signature key Pulumi uses to mark secret values in a state file
+const SecretSig = "4dabf18193072939515e22adb298388d"
+
+// secretSigValue is the value of the SecretSig key of secret values
+const secretSigValue = "1b47061264138c4ac30d75fd1eb44270"
+
+// IsSecret returns true if the value is a Pulumi secret; the encrypted value is stored in its ciphertext field
+func IsSecret(value gjson.Result) bool {
+	return value.IsObject() && value.Get(SecretSig).String() == secretSigValue
+}
+
 func (s *State) OutputKeys() ([]string, error) {
 	outputs, err := s.Outputs()
 	if err != nil {
@@ -122,6 +260,87 @@ func (s *State) GetOutput(name string, result interface{}) error {
 	return nil
 }
 
+// Project returns the name of the project the state belongs to, taken from the URN of its first resource. It is
+// empty if the state has no resources
+func (s *State) Project() (string, error) {
+	jsonB, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", err
+	}
+
+	urn := gjson.GetBytes(jsonB, "checkpoint.latest.resources.0.urn").String()
+	if urn == "" {
+		return "", nil
+	}
+
+	// urn:pulumi:<stack>::<project>::<type>::<name>
+	parts := strings.Split(urn, "::")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid urn %s", urn)
+	}
+	return parts[1], nil
+}
+
+// ListProjects returns the sorted names of all projects that have a local state. Like the file backend, it expects
+// the states of a project in ~/.pulumi/stacks/<project>/<stack>.json, so stacks without resources are listed as well
+func ListProjects() ([]string, error) {
+	stateDir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(stateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		stateFiles, err := findStateFiles(path.Join(stateDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if len(stateFiles) == 0 {
+			continue
+		}
+		projects = append(projects, entry.Name())
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// GetStacksForProject returns the local states of all stacks of the project, sorted by name
+func GetStacksForProject(projectName string) ([]*State, error) {
+	stateDir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	stateFiles, err := findStateFiles(stateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	states := []*State{}
+	for i := range stateFiles {
+		project, err := stateFiles[i].Project()
+		if err != nil {
+			return nil, err
+		}
+		if project == projectName {
+			states = append(states, &stateFiles[i])
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states, nil
+}
+
 func getStatesMap(stateFiles []State) (map[string]State, error) {
 	states := make(map[string]State)
 	for _, stateFile := range stateFiles {
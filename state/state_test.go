@@ -1,9 +1,12 @@
 package state
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 )
 
 func TestList(t *testing.T) {
@@ -33,3 +36,145 @@ func TestGetOutput(t *testing.T) {
 	require.NotEmpty(t, outputs)
 	require.Len(t, outputs, 4)
 }
+
+func TestIsSecret(t *testing.T) {
+	secret := gjson.Parse(`{"4dabf18193072939515e22adb298388d":"1b47061264138c4ac30d75fd1eb44270","ciphertext":"v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y="}`)
+	require.True(t, IsSecret(secret))
+
+	require.False(t, IsSecret(gjson.Parse(`{"ciphertext":"v1:fYYADOWNT7IqCV0V:DrMqOwJhAMQPuc6GssWyi7ggM9Y="}`)))
+	require.False(t, IsSecret(gjson.Parse(`"plain"`)))
+}
+
+func TestMatchType(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		typeToken string
+		want      bool
+	}{
+		{"kubernetes:core/v1:ConfigMap", "kubernetes:core/v1:ConfigMap", true},
+		{"kubernetes:core/v1:ConfigMap", "kubernetes:core/v1:Secret", false},
+		{"kubernetes:core/v1:*", "kubernetes:core/v1:Secret", true},
+		{"kubernetes:*", "kubernetes:apps/v1:Deployment", true},
+		{"*:ConfigMap", "kubernetes:core/v1:ConfigMap", true},
+		{"*:ConfigMap", "kubernetes:core/v1:Secret", false},
+		{"kubernetes:core/v?:Secret", "kubernetes:core/v1:Secret", true},
+		{"pulumi:pulumi:Stack", "pulumi:pulumi:StackReference", false},
+		{"*", "pulumi:providers:kubernetes", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.typeToken, func(t *testing.T) {
+			require.Equal(t, tt.want, MatchType(tt.pattern, tt.typeToken))
+		})
+	}
+}
+
+func TestFilterResourcesByType(t *testing.T) {
+	resources := []Resource{
+		{Type: "pulumi:pulumi:Stack"},
+		{Type: "kubernetes:core/v1:ConfigMap"},
+		{Type: "kubernetes:core/v1:Secret"},
+	}
+
+	filtered := FilterResourcesByType(resources, "kubernetes:core/v1:*")
+	require.Len(t, filtered, 2)
+
+	filtered = FilterResourcesByType(resources, "pulumi:pulumi:Stack")
+	require.Len(t, filtered, 1)
+}
+
+func TestResources(t *testing.T) {
+	s := writeState(t, "dev", `{"checkpoint":{"latest":{"resources":[
+		{"urn":"urn:pulumi:dev::demo::pulumi:pulumi:Stack::demo-dev","type":"pulumi:pulumi:Stack","outputs":{"name":"demo"}},
+		{"urn":"urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm","type":"kubernetes:core/v1:ConfigMap","id":"default/cm",
+			"inputs":{"data":{"a":"1"}},"dependencies":["urn:pulumi:dev::demo::pulumi:pulumi:Stack::demo-dev"]}
+	]}}}`)
+
+	resources, err := s.Resources()
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	cm := resources[1]
+	require.Equal(t, "urn:pulumi:dev::demo::kubernetes:core/v1:ConfigMap::cm", cm.URN)
+	require.Equal(t, "kubernetes:core/v1:ConfigMap", cm.Type)
+	require.Equal(t, "default/cm", cm.ID)
+	require.JSONEq(t, `{"a":"1"}`, string(cm.Inputs["data"]))
+	require.Equal(t, []string{"urn:pulumi:dev::demo::pulumi:pulumi:Stack::demo-dev"}, cm.Dependencies)
+
+	raw, err := s.ResourcesRaw()
+	require.NoError(t, err)
+	require.Equal(t, "demo", raw[0]["outputs"].Get("name").String())
+}
+
+// writeProjectStates writes a local state per stack name whose resources belong to the given project
+func writeProjectStates(t *testing.T, stacks map[string]string) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	stacksDir := path.Join(home, ".pulumi", "stacks")
+	require.NoError(t, os.MkdirAll(stacksDir, 0755))
+	for name, project := range stacks {
+		content := `{"checkpoint":{"latest":{"resources":[]}}}`
+		if project != "" {
+			content = `{"checkpoint":{"latest":{"resources":[{"urn":"urn:pulumi:` + name + `::` + project + `::pulumi:pulumi:Stack::` + project + `-` + name + `","type":"pulumi:pulumi:Stack"}]}}}`
+		}
+		require.NoError(t, os.WriteFile(path.Join(stacksDir, name+".json"), []byte(content), 0644))
+	}
+}
+
+func TestListProjects(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	stacksDir := path.Join(home, ".pulumi", "stacks")
+	files := map[string]string{
+		"web/dev.json":   `{"checkpoint":{"latest":{"resources":[{"urn":"urn:pulumi:dev::web::pulumi:pulumi:Stack::web-dev","type":"pulumi:pulumi:Stack"}]}}}`,
+		"demo/dev.json":  `{"checkpoint":{"latest":{"resources":[]}}}`,
+		"demo/prod.json": `{"checkpoint":{"latest":{}}}`,
+		// an empty stack of a project that was never deployed
+		"api/dev.json": `{"checkpoint":{}}`,
+		// no state file, only a backup
+		"old/dev.json.bak": `{}`,
+		// a state without a project directory
+		"legacy.json": `{}`,
+	}
+	for name, content := range files {
+		p := path.Join(stacksDir, name)
+		require.NoError(t, os.MkdirAll(path.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	projects, err := ListProjects()
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "demo", "web"}, projects)
+}
+
+func TestGetStacksForProject(t *testing.T) {
+	writeProjectStates(t, map[string]string{"dev": "demo", "prod": "demo", "web-dev": "web", "empty": ""})
+
+	states, err := GetStacksForProject("demo")
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.Equal(t, "dev", states[0].Name)
+	require.Equal(t, "prod", states[1].Name)
+
+	states, err = GetStacksForProject("missing")
+	require.NoError(t, err)
+	require.Empty(t, states)
+}
+
+func TestGetResourceByID(t *testing.T) {
+	s := writeState(t, "dev", `{"checkpoint":{"latest":{"resources":[
+		{"urn":"urn:pulumi:dev::demo::pulumi:pulumi:Stack::demo-dev","type":"pulumi:pulumi:Stack"},
+		{"urn":"urn:pulumi:dev::demo::aws:s3/bucket:Bucket::logs","type":"aws:s3/bucket:Bucket","id":"arn:aws:s3:::logs"}
+	]}}}`)
+
+	resource, err := s.GetResourceByID("arn:aws:s3:::logs")
+	require.NoError(t, err)
+	require.Equal(t, "urn:pulumi:dev::demo::aws:s3/bucket:Bucket::logs", resource.URN)
+
+	_, err = s.GetResourceByID("arn:aws:s3:::missing")
+	var notFound *ResourceNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, "arn:aws:s3:::missing", notFound.ID)
+}
@@ -0,0 +1,47 @@
+package state
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watch polls the local state files every interval and sends the states to ch initially and whenever a state file
+// was added, removed or modified. It blocks until the context is done
+func Watch(ctx context.Context, interval time.Duration, ch chan<- []State) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastFingerprint := ""
+	for {
+		states, err := List()
+		if err != nil {
+			return err
+		}
+
+		if current := fingerprint(states); current != lastFingerprint {
+			lastFingerprint = current
+			select {
+			case ch <- states:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func fingerprint(states []State) string {
+	entries := make([]string, 0, len(states))
+	for _, s := range states {
+		entries = append(entries, s.Path+"@"+s.ModTime.String())
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, "\n")
+}
@@ -0,0 +1,17 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// ResourceOutputToString coerces any output into its string representation, e.g. for debugging, logging or tagging
+func ResourceOutputToString(o pulumi.AnyOutput) pulumi.StringOutput {
+	return o.ApplyT(func(v interface{}) string {
+		if s, ok := v.(string); ok {
+			return s
+		}
+		return fmt.Sprintf("%v", v)
+	}).(pulumi.StringOutput)
+}
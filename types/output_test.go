@@ -0,0 +1,41 @@
+package types
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mheers/pulumi-helper/mocks"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceOutputToString(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm, err := corev1.NewConfigMap(ctx, "cm", &corev1.ConfigMapArgs{
+			Data: pulumi.StringMap{"key": pulumi.String("value")},
+		})
+		if err != nil {
+			return err
+		}
+
+		data := cm.Data.ApplyT(func(d map[string]string) interface{} {
+			return d
+		}).(pulumi.AnyOutput)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		ResourceOutputToString(data).ApplyT(func(s string) error {
+			require.NotEmpty(t, s)
+			require.Equal(t, "map[key:value]", s)
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
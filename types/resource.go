@@ -1,8 +1,12 @@
 package types
 
 import (
+	"fmt"
+	"reflect"
 	"sync"
 
+	"github.com/mheers/pulumi-helper/state"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
@@ -29,6 +33,42 @@ func MergeResourceArrayOutputs(resourceArrayOutputs []pulumi.ResourceArrayOutput
 	}).(pulumi.ResourceArrayOutput)
 }
 
+// ResourceMapOutput is an Output of a map of named resources
+type ResourceMapOutput struct{ *pulumi.OutputState }
+
+func (ResourceMapOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*map[string]pulumi.Resource)(nil)).Elem()
+}
+
+func init() {
+	pulumi.RegisterOutputType(ResourceMapOutput{})
+}
+
+// ToResourceMapOutput wraps a map of named resources into a ResourceMapOutput
+func ToResourceMapOutput(resourceMap map[string]pulumi.Resource) ResourceMapOutput {
+	return pulumi.All().ApplyT(func(_ []interface{}) map[string]pulumi.Resource {
+		return resourceMap
+	}).(ResourceMapOutput)
+}
+
+// MergeResourceMapOutputs merges the maps into one. On key collisions the later map wins
+func MergeResourceMapOutputs(maps []ResourceMapOutput) ResourceMapOutput {
+	inputs := make([]interface{}, len(maps))
+	for i, m := range maps {
+		inputs[i] = m
+	}
+
+	return pulumi.All(inputs...).ApplyT(func(vs []interface{}) map[string]pulumi.Resource {
+		merged := map[string]pulumi.Resource{}
+		for _, v := range vs {
+			for name, r := range v.(map[string]pulumi.Resource) {
+				merged[name] = r
+			}
+		}
+		return merged
+	}).(ResourceMapOutput)
+}
+
 func ResourceMapToSlice(resourceMap map[string]pulumi.Resource) []pulumi.Resource {
 	var resources []pulumi.Resource
 	for _, resource := range resourceMap {
@@ -36,3 +76,84 @@ func ResourceMapToSlice(resourceMap map[string]pulumi.Resource) []pulumi.Resourc
 	}
 	return resources
 }
+
+// ToResourceMap indexes the resources by their logical name. This is the inverse of ResourceMapToSlice; as the names
+// are taken from the URNs the map is an output
+func ToResourceMap(resources []pulumi.Resource) ResourceMapOutput {
+	urns := make([]interface{}, len(resources))
+	for i, r := range resources {
+		urns[i] = r.URN()
+	}
+
+	return pulumi.All(urns...).ApplyT(func(vs []interface{}) map[string]pulumi.Resource {
+		resourceMap := map[string]pulumi.Resource{}
+		for i, v := range vs {
+			urn := resource.URN(v.(pulumi.URN))
+			resourceMap[urn.Name()] = resources[i]
+		}
+		return resourceMap
+	}).(ResourceMapOutput)
+}
+
+// FilterResourceArrayOutput keeps only the resources whose type token matches typeToken. The same glob patterns as
+// in state.FilterResourcesByType are supported
+func FilterResourceArrayOutput(arr pulumi.ResourceArrayOutput, typeToken string) pulumi.ResourceArrayOutput {
+	return arr.ApplyT(func(resources []pulumi.Resource) pulumi.ResourceArrayOutput {
+		urns := make([]interface{}, len(resources))
+		for i, r := range resources {
+			urns[i] = r.URN()
+		}
+
+		return pulumi.All(urns...).ApplyT(func(vs []interface{}) []pulumi.Resource {
+			filtered := []pulumi.Resource{}
+			for i, v := range vs {
+				urn := resource.URN(v.(pulumi.URN))
+				if state.MatchType(typeToken, string(urn.Type())) {
+					filtered = append(filtered, resources[i])
+				}
+			}
+			return filtered
+		}).(pulumi.ResourceArrayOutput)
+	}).(pulumi.ResourceArrayOutput)
+}
+
+// ResourceArrayOutputToURNs returns the URNs of all resources in arr
+func ResourceArrayOutputToURNs(arr pulumi.ResourceArrayOutput) pulumi.StringArrayOutput {
+	return arr.ApplyT(func(resources []pulumi.Resource) pulumi.StringArrayOutput {
+		urns := make([]interface{}, len(resources))
+		for i, r := range resources {
+			urns[i] = r.URN()
+		}
+
+		return pulumi.All(urns...).ApplyT(func(vs []interface{}) []string {
+			result := make([]string, len(vs))
+			for i, v := range vs {
+				result[i] = string(v.(pulumi.URN))
+			}
+			return result
+		}).(pulumi.StringArrayOutput)
+	}).(pulumi.StringArrayOutput)
+}
+
+// ResourceArrayOutputToNames returns the logical names of all resources in arr
+func ResourceArrayOutputToNames(arr pulumi.ResourceArrayOutput) pulumi.StringArrayOutput {
+	return ResourceArrayOutputToURNs(arr).ApplyT(func(urns []string) []string {
+		names := make([]string, len(urns))
+		for i, urn := range urns {
+			names[i] = resource.URN(urn).Name()
+		}
+		return names
+	}).(pulumi.StringArrayOutput)
+}
+
+// WaitForAllResources returns an output that resolves to the URNs of all resources once all of them are registered
+func WaitForAllResources(resources []pulumi.Resource) (pulumi.Output, error) {
+	urns := make([]interface{}, len(resources))
+	for i, r := range resources {
+		if r == nil {
+			return nil, fmt.Errorf("resource at index %d is nil", i)
+		}
+		urns[i] = r.URN()
+	}
+	return pulumi.All(urns...), nil
+}
@@ -11,6 +11,7 @@ import (
 )
 
 func TestMergeResources(t *testing.T) {
+	counter := mocks.NewCountingMocks(mocks.Mocks(0))
 	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
 		cm1, err := corev1.NewConfigMap(ctx, "cm1", &corev1.ConfigMapArgs{})
 		if err != nil {
@@ -45,6 +46,186 @@ func TestMergeResources(t *testing.T) {
 
 		wg.Wait()
 
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", counter))
+	require.NoError(t, err)
+
+	counter.AssertCreated(t, "kubernetes:core/v1:ConfigMap", 2)
+	counter.AssertNotCreated(t, "kubernetes:core/v1:Secret")
+}
+
+func TestFilterResourceArrayOutput(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm, err := corev1.NewConfigMap(ctx, "cm", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		secret, err := corev1.NewSecret(ctx, "secret", &corev1.SecretArgs{})
+		if err != nil {
+			return err
+		}
+
+		resources := pulumi.ToResourceArray([]pulumi.Resource{cm, secret}).ToResourceArrayOutput()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		FilterResourceArrayOutput(resources, "kubernetes:core/v1:ConfigMap").ApplyT(func(arr []pulumi.Resource) error {
+			require.Len(t, arr, 1)
+			require.Equal(t, cm, arr[0])
+			wg.Done()
+			return nil
+		})
+
+		FilterResourceArrayOutput(resources, "kubernetes:core/v1:*").ApplyT(func(arr []pulumi.Resource) error {
+			require.Len(t, arr, 2)
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestResourceArrayOutputToURNsAndNames(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm1, err := corev1.NewConfigMap(ctx, "cm1", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		cm2, err := corev1.NewConfigMap(ctx, "cm2", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		resources := pulumi.ToResourceArray([]pulumi.Resource{cm1, cm2}).ToResourceArrayOutput()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		ResourceArrayOutputToURNs(resources).ApplyT(func(urns []string) error {
+			require.Equal(t, []string{
+				"urn:pulumi:demo-stack::demo-project::kubernetes:core/v1:ConfigMap::cm1",
+				"urn:pulumi:demo-stack::demo-project::kubernetes:core/v1:ConfigMap::cm2",
+			}, urns)
+			wg.Done()
+			return nil
+		})
+
+		ResourceArrayOutputToNames(resources).ApplyT(func(names []string) error {
+			require.Equal(t, []string{"cm1", "cm2"}, names)
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestWaitForAllResources(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm1, err := corev1.NewConfigMap(ctx, "cm1", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		cm2, err := corev1.NewConfigMap(ctx, "cm2", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		_, err = WaitForAllResources([]pulumi.Resource{cm1, nil})
+		require.Error(t, err)
+
+		done, err := WaitForAllResources([]pulumi.Resource{cm1, cm2})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		done.ApplyT(func(urns []interface{}) error {
+			require.Len(t, urns, 2)
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestMergeResourceMapOutputs(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm1, err := corev1.NewConfigMap(ctx, "cm1", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		cm2, err := corev1.NewConfigMap(ctx, "cm2", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		merged := MergeResourceMapOutputs([]ResourceMapOutput{
+			ToResourceMapOutput(map[string]pulumi.Resource{"a": cm1, "shared": cm1}),
+			ToResourceMapOutput(map[string]pulumi.Resource{"b": cm2, "shared": cm2}),
+		})
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		merged.ApplyT(func(m map[string]pulumi.Resource) error {
+			require.Len(t, m, 3)
+			require.Equal(t, cm1, m["a"])
+			require.Equal(t, cm2, m["b"])
+			require.Equal(t, cm2, m["shared"])
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
+	require.NoError(t, err)
+}
+
+func TestToResourceMap(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		cm1, err := corev1.NewConfigMap(ctx, "cm1", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		cm2, err := corev1.NewConfigMap(ctx, "cm2", &corev1.ConfigMapArgs{})
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		ToResourceMap([]pulumi.Resource{cm1, cm2}).ApplyT(func(m map[string]pulumi.Resource) error {
+			require.Len(t, m, 2)
+			require.Equal(t, cm1, m["cm1"])
+			require.Equal(t, cm2, m["cm2"])
+			require.ElementsMatch(t, []pulumi.Resource{cm1, cm2}, ResourceMapToSlice(m))
+			wg.Done()
+			return nil
+		})
+
+		wg.Wait()
+
 		return nil
 	}, pulumi.WithMocks("demo-project", "demo-stack", mocks.Mocks(0)))
 	require.NoError(t, err)
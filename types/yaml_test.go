@@ -21,3 +21,20 @@ name: marcel
 	require.NotEmpty(t, y)
 	require.Equal(t, yamlBytes, y)
 }
+
+func TestYamlBytesToJSONBytesRoundTrip(t *testing.T) {
+	yamlBytes := []byte(`hobbies:
+- music
+- computer
+- sport
+name: marcel
+`)
+
+	j, err := YamlBytesToJSONBytes(yamlBytes)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"name": "marcel", "hobbies": ["music", "computer", "sport"]}`, string(j))
+
+	y, err := JsonBytesToYamlBytes(j)
+	require.Nil(t, err)
+	require.Equal(t, yamlBytes, y)
+}
@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	pulumiworkspace "github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+// HealthReport lists the problems found in a workspace file
+type HealthReport struct {
+	Healthy bool
+	Issues  []string
+}
+
+// WorkspaceHealth checks that the workspace file is valid JSON, that its stack exists and that the project name in the
+// file name matches the Pulumi.yaml. Like the Pulumi CLI, the project is the nearest Pulumi.yaml from the current
+// directory upwards; the hash in the workspace file name has to belong to that Pulumi.yaml
+func WorkspaceHealth(w *Workspace) (*HealthReport, error) {
+	report := &HealthReport{}
+
+	data, err := os.ReadFile(w.File.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]interface{})
+	jsonErr := json.Unmarshal(data, &settings)
+	if jsonErr != nil {
+		report.Issues = append(report.Issues, fmt.Sprintf("workspace file is not valid JSON: %s", jsonErr))
+	}
+
+	stackName, _ := settings["stack"].(string)
+	if jsonErr == nil && stackName == "" {
+		report.Issues = append(report.Issues, "workspace file has no stack")
+	}
+
+	projectFile, err := pulumiworkspace.DetectProjectPath()
+	if errors.Is(err, pulumiworkspace.ErrProjectNotFound) {
+		report.Issues = append(report.Issues, "no Pulumi.yaml found in the current directory or its parents")
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the Pulumi CLI names the workspace file after the SHA-1 of the path of the Pulumi.yaml
+	if w.Hash != "" && w.Hash != sha1Hex(projectFile) {
+		report.Issues = append(report.Issues, fmt.Sprintf("workspace file does not belong to %s", projectFile))
+	}
+
+	if stackName != "" {
+		_, err = os.Stat(path.Join(filepath.Dir(projectFile), fmt.Sprintf("Pulumi.%s.yaml", stackName)))
+		if err != nil {
+			report.Issues = append(report.Issues, fmt.Sprintf("stack file of stack %s not found", stackName))
+		}
+	}
+
+	projectName, err := projectNameFromFile(projectFile)
+	if err != nil {
+		report.Issues = append(report.Issues, fmt.Sprintf("could not read %s: %s", projectFile, err))
+	} else if projectName != w.Name {
+		report.Issues = append(report.Issues, fmt.Sprintf("workspace project %s does not match project %s", w.Name, projectName))
+	}
+
+	report.Healthy = len(report.Issues) == 0
+	return report, nil
+}
+
+func sha1Hex(value string) string {
+	h := sha1.Sum([]byte(value))
+	return hex.EncodeToString(h[:])
+}
+
+func projectName(projectDir string) (string, error) {
+	return projectNameFromFile(path.Join(projectDir, "Pulumi.yaml"))
+}
+
+func projectNameFromFile(projectFile string) (string, error) {
+	data, err := os.ReadFile(projectFile)
+	if err != nil {
+		return "", err
+	}
+
+	project := struct {
+		Name string `yaml:"name"`
+	}{}
+	err = yaml.Unmarshal(data, &project)
+	if err != nil {
+		return "", err
+	}
+	return project.Name, nil
+}
@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+// chdir changes the working directory for the rest of the test
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestWorkspaceHealth(t *testing.T) {
+	projectDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(projectDir, "Pulumi.yaml"), []byte("name: demo\nruntime: go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(projectDir, "Pulumi.dev.yaml"), []byte("config: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	subDir := path.Join(projectDir, "internal")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// the project is found from subdirectories as well
+	chdir(t, subDir)
+
+	projectHash := sha1Hex(path.Join(projectDir, "Pulumi.yaml"))
+
+	tests := []struct {
+		name       string
+		workspace  string
+		hash       string
+		content    string
+		wantIssues int
+	}{
+		{"healthy", "demo", projectHash, `{"stack":"dev"}`, 0},
+		{"healthy without hash", "demo", "", `{"stack":"dev"}`, 0},
+		{"other project directory", "demo", sha1Hex("/elsewhere/Pulumi.yaml"), `{"stack":"dev"}`, 1},
+		{"missing stack", "demo", projectHash, `{"stack":"prod"}`, 1},
+		{"invalid json", "demo", projectHash, `{"stack":`, 1},
+		{"no stack", "demo", projectHash, `{}`, 1},
+		{"project mismatch", "other", "", `{"stack":"dev"}`, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := path.Join(t.TempDir(), tt.workspace+"-workspace.json")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			w := &Workspace{
+				File: WorkspaceFile{Path: filePath},
+				Name: tt.workspace,
+				Hash: tt.hash,
+			}
+			report, err := WorkspaceHealth(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(report.Issues) != tt.wantIssues {
+				t.Errorf("WorkspaceHealth() issues = %v, want %d issues", report.Issues, tt.wantIssues)
+			}
+			if report.Healthy != (tt.wantIssues == 0) {
+				t.Errorf("WorkspaceHealth() healthy = %v", report.Healthy)
+			}
+		})
+	}
+}
+
+func TestWorkspaceHealthWithoutProject(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	filePath := path.Join(t.TempDir(), "demo-workspace.json")
+	if err := os.WriteFile(filePath, []byte(`{"stack":"dev"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := WorkspaceHealth(&Workspace{File: WorkspaceFile{Path: filePath}, Name: "demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Healthy || len(report.Issues) != 1 {
+		t.Errorf("WorkspaceHealth() = %+v, want one issue", report)
+	}
+}
@@ -2,7 +2,9 @@ package workspace
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
 	"strings"
@@ -23,6 +25,50 @@ func List() ([]Workspace, error) {
 	return result, nil
 }
 
+// MostRecentWorkspace returns the workspace that was modified last across all projects
+func MostRecentWorkspace() (*Workspace, error) {
+	workspaces, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(workspaces) == 0 {
+		return nil, fmt.Errorf("no workspaces found")
+	}
+
+	mostRecent := workspaces[0]
+	for _, workspace := range workspaces[1:] {
+		if workspace.File.ModTime.After(mostRecent.File.ModTime) {
+			mostRecent = workspace
+		}
+	}
+	return &mostRecent, nil
+}
+
+// ErrNoWorkspace is returned when a project has no workspace yet, i.e. no stack was ever selected for it
+var ErrNoWorkspace = errors.New("no workspace found")
+
+// FindWorkspaceForDir returns the workspace of the Pulumi project in dir
+func FindWorkspaceForDir(dir string) (*Workspace, error) {
+	name, err := projectName(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := GetWorkspaces()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w for project %s", ErrNoWorkspace, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, ok := workspaces[name]
+	if !ok {
+		return nil, fmt.Errorf("%w for project %s", ErrNoWorkspace, name)
+	}
+	return &workspace, nil
+}
+
 func GetWorkspaces() (map[string]Workspace, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -76,6 +122,22 @@ func (w *Workspace) SetStack(name string) error {
 	return nil
 }
 
+// Refresh re-reads the workspace file and updates the stack and the modification time in place
+func (w *Workspace) Refresh() error {
+	info, err := os.Stat(w.File.Path)
+	if err != nil {
+		return err
+	}
+
+	err = w.initStack()
+	if err != nil {
+		return err
+	}
+
+	w.File.ModTime = info.ModTime()
+	return nil
+}
+
 func (w *Workspace) initStack() error {
 	data, err := os.ReadFile(w.File.Path)
 	if err != nil {
@@ -1,6 +1,14 @@
 package workspace
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
 
 func TestGetWorkspaceNameAndHashFromFile(t *testing.T) {
 	tests := []struct {
@@ -36,3 +44,108 @@ func TestGetWorkspaceNameAndHashFromFile(t *testing.T) {
 	}
 
 }
+
+func TestMostRecentWorkspace(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	workspaceDir := path.Join(home, ".pulumi", "workspaces")
+	require.NoError(t, os.MkdirAll(workspaceDir, 0755))
+
+	files := []struct {
+		name    string
+		stack   string
+		modTime time.Time
+	}{
+		{"demo-049bc369530d2f05a8ba2cdbbb49164cfd3ba066-workspace.json", "dev", time.Now().Add(-2 * time.Hour)},
+		{"other-149bc369530d2f05a8ba2cdbbb49164cfd3ba066-workspace.json", "prod", time.Now().Add(-time.Hour)},
+		{"third-249bc369530d2f05a8ba2cdbbb49164cfd3ba066-workspace.json", "test", time.Now().Add(-3 * time.Hour)},
+	}
+	for _, f := range files {
+		p := path.Join(workspaceDir, f.name)
+		require.NoError(t, os.WriteFile(p, []byte(`{"stack":"`+f.stack+`"}`), 0644))
+		require.NoError(t, os.Chtimes(p, f.modTime, f.modTime))
+	}
+
+	got, err := MostRecentWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "other" || got.Stack != "prod" {
+		t.Errorf("MostRecentWorkspace() got = %s/%s, want other/prod", got.Name, got.Stack)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	file := path.Join(t.TempDir(), "demo-049bc369530d2f05a8ba2cdbbb49164cfd3ba066-workspace.json")
+	err := os.WriteFile(file, []byte(`{"stack":"dev"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := Workspace{File: WorkspaceFile{Path: file}}
+	err = w.Refresh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Stack != "dev" {
+		t.Errorf("expected stack dev, got %s", w.Stack)
+	}
+
+	// another process switches the stack
+	err = os.WriteFile(file, []byte(`{"stack":"prod"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Refresh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Stack != "prod" {
+		t.Errorf("expected stack prod, got %s", w.Stack)
+	}
+	if w.File.ModTime.IsZero() {
+		t.Error("expected the modification time to be set")
+	}
+
+	os.Remove(file)
+	if w.Refresh() == nil {
+		t.Error("expected an error for a removed workspace file")
+	}
+}
+
+func TestFindWorkspaceForDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	projectDir := t.TempDir()
+	err := os.WriteFile(path.Join(projectDir, "Pulumi.yaml"), []byte("name: demo\nruntime: go\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FindWorkspaceForDir(projectDir)
+	if !errors.Is(err, ErrNoWorkspace) {
+		t.Errorf("expected ErrNoWorkspace, got %v", err)
+	}
+
+	workspaceDir := path.Join(home, ".pulumi", "workspaces")
+	require.NoError(t, os.MkdirAll(workspaceDir, 0755))
+	err = os.WriteFile(path.Join(workspaceDir, "demo-049bc369530d2f05a8ba2cdbbb49164cfd3ba066-workspace.json"), []byte(`{"stack":"dev"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := FindWorkspaceForDir(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Name != "demo" || w.Stack != "dev" {
+		t.Errorf("unexpected workspace %+v", w)
+	}
+
+	_, err = FindWorkspaceForDir(t.TempDir())
+	if err == nil || errors.Is(err, ErrNoWorkspace) {
+		t.Errorf("expected an error for a directory without Pulumi.yaml, got %v", err)
+	}
+}